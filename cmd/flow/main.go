@@ -2,8 +2,11 @@ package main
 
 import (
 	"github.com/flowswiss/cli/v2/internal/commands"
+	"github.com/flowswiss/cli/v2/internal/commands/auth"
+	"github.com/flowswiss/cli/v2/internal/commands/cache"
 	"github.com/flowswiss/cli/v2/internal/commands/common"
 	"github.com/flowswiss/cli/v2/internal/commands/compute"
+	"github.com/flowswiss/cli/v2/internal/commands/config"
 	"github.com/flowswiss/cli/v2/internal/commands/kubernetes"
 	"github.com/flowswiss/cli/v2/internal/commands/macbaremetal"
 	"github.com/flowswiss/cli/v2/internal/commands/objectstorage"
@@ -19,11 +22,16 @@ func main() {
 		Endpoint:    "https://api.flow.swiss/",
 
 		Modules: []commands.ModuleFactory{
+			auth.Module,
+			cache.Module,
+
 			common.Location,
 			common.Module,
+			common.Order,
 			common.Product,
 
 			compute.Module,
+			config.Module,
 			kubernetes.Module,
 			macbaremetal.Module,
 			objectstorage.Module,
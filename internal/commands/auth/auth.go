@@ -0,0 +1,218 @@
+package auth
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/flowswiss/cli/v2/internal/commands"
+	"github.com/flowswiss/cli/v2/pkg/api/common"
+	"github.com/flowswiss/cli/v2/pkg/console"
+)
+
+func Module(app commands.Application) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "auth",
+		Short: "Manage authentication",
+		Example: commands.FormatExamples(fmt.Sprintf(`
+      # Log in interactively
+      %[1]s auth login
+      
+      # Log in into a separate profile using a token from a file
+      %[1]s --profile staging auth login --with-token < token.txt
+      
+      # Show whether the current token is valid
+      %[1]s auth status
+		`, app.Name)),
+		Annotations: map[string]string{
+			commands.AnnotationSkipAuthentication: "",
+		},
+	}
+
+	commands.Add(app, cmd,
+		&loginCommand{},
+		&logoutCommand{},
+		&statusCommand{},
+	)
+
+	return cmd
+}
+
+type loginCommand struct {
+	app       commands.Application
+	withToken bool
+}
+
+func (l *loginCommand) Run(cmd *cobra.Command, args []string) error {
+	verify := func(token string) error {
+		return verifyToken(cmd.Context(), l.app, token)
+	}
+
+	var token string
+	var err error
+
+	if l.withToken || !commands.Config.Terminal {
+		token, err = bufio.NewReader(os.Stdin).ReadString('\n')
+		if err != nil && len(token) == 0 {
+			return fmt.Errorf("read token: %w", err)
+		}
+
+		token = strings.TrimSpace(token)
+		if err = verify(token); err != nil {
+			return err
+		}
+	} else {
+		token, err = console.Password(commands.Stderr, "Application Token", verify)
+		if err != nil {
+			return fmt.Errorf("read token: %w", err)
+		}
+	}
+
+	err = commands.EditConfig(func(file *viper.Viper) error {
+		file.Set(commands.ProfileSettingKey(commands.Config.Profile, commands.FlagToken), token)
+
+		if cmd.Flags().Changed(commands.FlagEndpoint) {
+			file.Set(commands.ProfileSettingKey(commands.Config.Profile, commands.FlagEndpoint), viper.GetString(commands.FlagEndpoint))
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("save token: %w", err)
+	}
+
+	commands.Stderr.Printf("Successfully logged in to %s\n", viper.GetString(commands.FlagEndpoint))
+	return nil
+}
+
+func (l *loginCommand) Build(app commands.Application) *cobra.Command {
+	l.app = app
+
+	cmd := &cobra.Command{
+		Use:   "login",
+		Short: "Log in using an application token",
+		Long: commands.FormatHelp(`
+			Prompts for an application token, verifies it against the api and stores it in the config file.
+
+			The token is stored in the currently active profile. If no profile is active, the token is stored globally.
+			A new application token can be created in the organization settings of the Flow Swiss portal.
+		`),
+		Args: cobra.NoArgs,
+		RunE: l.Run,
+	}
+
+	cmd.Flags().BoolVar(&l.withToken, "with-token", false, "read the token from standard input instead of prompting for it")
+
+	return cmd
+}
+
+type logoutCommand struct {
+}
+
+func (l *logoutCommand) Run(cmd *cobra.Command, args []string) error {
+	err := commands.UnsetConfig(commands.ProfileSettingKey(commands.Config.Profile, commands.FlagToken))
+	if err != nil {
+		return fmt.Errorf("remove token: %w", err)
+	}
+
+	commands.Stderr.Println("Successfully logged out")
+	return nil
+}
+
+func (l *logoutCommand) Build(app commands.Application) *cobra.Command {
+	return &cobra.Command{
+		Use:   "logout",
+		Short: "Log out",
+		Long:  "Removes the stored token of the currently active profile from the config file.",
+		Args:  cobra.NoArgs,
+		RunE:  l.Run,
+	}
+}
+
+type Status struct {
+	Profile  string `json:"profile"`
+	Endpoint string `json:"endpoint"`
+	Valid    bool   `json:"valid"`
+	Error    string `json:"error,omitempty"`
+}
+
+func (s Status) Columns() []string {
+	return []string{"profile", "endpoint", "token"}
+}
+
+func (s Status) Values() map[string]interface{} {
+	token := "valid"
+	if !s.Valid {
+		token = s.Error
+	}
+
+	return map[string]interface{}{
+		"profile":  s.Profile,
+		"endpoint": s.Endpoint,
+		"token":    token,
+	}
+}
+
+type statusCommand struct {
+	app commands.Application
+}
+
+func (s *statusCommand) Run(cmd *cobra.Command, args []string) error {
+	status := Status{
+		Profile:  commands.Config.Profile,
+		Endpoint: viper.GetString(commands.FlagEndpoint),
+	}
+
+	token, err := commands.Token()
+	if err == nil {
+		err = verifyToken(cmd.Context(), s.app, token)
+	}
+
+	if err != nil {
+		status.Error = err.Error()
+	} else {
+		status.Valid = true
+	}
+
+	if err := commands.PrintStdout(status); err != nil {
+		return err
+	}
+
+	// the status is already printed, only the exit code indicates the failure
+	if !status.Valid {
+		cmd.SilenceUsage = true
+		return fmt.Errorf("not authenticated")
+	}
+
+	return nil
+}
+
+func (s *statusCommand) Build(app commands.Application) *cobra.Command {
+	s.app = app
+
+	return &cobra.Command{
+		Use:   "status",
+		Short: "Show authentication status",
+		Long:  "Prints the active profile and endpoint and verifies whether the configured token is valid. Exits with a non-zero status if it is not.",
+		Args:  cobra.NoArgs,
+		RunE:  s.Run,
+	}
+}
+
+func verifyToken(ctx context.Context, app commands.Application, token string) error {
+	if len(token) == 0 {
+		return fmt.Errorf("missing authentication token")
+	}
+
+	_, err := common.Locations(ctx, commands.NewUncachedClient(app, token))
+	if err != nil {
+		return fmt.Errorf("verify token: %w", err)
+	}
+
+	return nil
+}
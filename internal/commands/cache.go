@@ -0,0 +1,134 @@
+package commands
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httputil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// cachedPaths contains the api paths of catalog data, which rarely changes
+// and can therefore be cached on disk.
+var cachedPaths = []string{
+	"/v4/entities/locations",
+	"/v4/entities/modules",
+	"/v4/entities/product-types",
+	"/v4/products",
+	"/v4/entities/compute/images",
+	"/v4/entities/compute/load-balancer-protocols",
+	"/v4/entities/compute/load-balancer-algorithms",
+	"/v4/entities/compute/load-balancer-health-check-types",
+}
+
+// CacheDir returns the directory containing the cached api responses of all
+// endpoints and profiles.
+func CacheDir() string {
+	return filepath.Join(configDir, "cache")
+}
+
+// ClearCache removes all cached api responses.
+func ClearCache() error {
+	return os.RemoveAll(CacheDir())
+}
+
+// cacheDirFor returns the cache directory of the given endpoint and profile.
+func cacheDirFor(endpoint, profile string) string {
+	sum := sha256.Sum256([]byte(endpoint + "\x00" + profile))
+	return filepath.Join(CacheDir(), hex.EncodeToString(sum[:8]))
+}
+
+var _ http.RoundTripper = (*cacheTransport)(nil)
+
+type cacheTransport struct {
+	delegate http.RoundTripper
+	dir      string
+	ttl      time.Duration
+}
+
+func (c cacheTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet || !isCachedPath(req.URL.Path) {
+		return c.base().RoundTrip(req)
+	}
+
+	sum := sha256.Sum256([]byte(req.URL.String()))
+	file := filepath.Join(c.dir, hex.EncodeToString(sum[:]))
+
+	if res, ok := c.read(file, req); ok {
+		Log.Print(LogLevelDebug, LogEntry{Message: "cached response", Method: req.Method, URL: req.URL.String(), Status: res.StatusCode})
+		return res, nil
+	}
+
+	res, err := c.base().RoundTrip(req)
+	if err != nil || res.StatusCode != http.StatusOK {
+		return res, err
+	}
+
+	// dumping the response replaces the body, so the response can still be
+	// consumed afterwards
+	data, err := httputil.DumpResponse(res, true)
+	if err != nil {
+		return nil, err
+	}
+
+	c.write(file, data)
+	return res, nil
+}
+
+func (c cacheTransport) read(file string, req *http.Request) (*http.Response, bool) {
+	info, err := os.Stat(file)
+	if err != nil || time.Since(info.ModTime()) > c.ttl {
+		return nil, false
+	}
+
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, false
+	}
+
+	res, err := http.ReadResponse(bufio.NewReader(bytes.NewReader(data)), req)
+	if err != nil {
+		return nil, false
+	}
+
+	return res, true
+}
+
+// write stores the response in the cache. Failures are ignored, as the cache
+// is purely an optimization.
+func (c cacheTransport) write(file string, data []byte) {
+	if err := os.MkdirAll(c.dir, 0700); err != nil {
+		return
+	}
+
+	tmp := file + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return
+	}
+
+	_ = os.Rename(tmp, file)
+}
+
+func (c cacheTransport) base() http.RoundTripper {
+	if c.delegate == nil {
+		return http.DefaultTransport
+	}
+
+	return c.delegate
+}
+
+func isCachedPath(path string) bool {
+	// the endpoint might contain a base path in front of the api path
+	for _, prefix := range cachedPaths {
+		if strings.Contains(path+"/", prefix+"/") {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,61 @@
+package cache
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/flowswiss/cli/v2/internal/commands"
+)
+
+func Module(app commands.Application) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cache",
+		Short: "Manage the cache of catalog data",
+		Long: commands.FormatHelp(`
+			Catalog data like locations, modules, products, images and load balancer entities rarely changes and is
+			therefore cached on disk. The cache is kept separately for every endpoint and profile.
+
+			Cached data expires after the duration configured using the "cache-ttl" setting. Use the "--no-cache" flag to
+			bypass the cache for a single command.
+		`),
+		Example: commands.FormatExamples(fmt.Sprintf(`
+      # Remove all cached data
+      %[1]s cache clear
+      
+      # Cache catalog data for one hour only
+      %[1]s config set cache-ttl 1h
+		`, app.Name)),
+		Annotations: map[string]string{
+			commands.AnnotationSkipAuthentication: "",
+		},
+	}
+
+	commands.Add(app, cmd,
+		&clearCommand{},
+	)
+
+	return cmd
+}
+
+type clearCommand struct {
+}
+
+func (c *clearCommand) Run(cmd *cobra.Command, args []string) error {
+	if err := commands.ClearCache(); err != nil {
+		return fmt.Errorf("clear cache: %w", err)
+	}
+
+	commands.Stderr.Println("Successfully cleared the cache")
+	return nil
+}
+
+func (c *clearCommand) Build(app commands.Application) *cobra.Command {
+	return &cobra.Command{
+		Use:   "clear",
+		Short: "Clear cache",
+		Long:  "Removes all cached catalog data of every endpoint and profile.",
+		Args:  cobra.NoArgs,
+		RunE:  c.Run,
+	}
+}
@@ -0,0 +1,247 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/spf13/viper"
+)
+
+const cassetteVersion = 1
+
+// Cassette contains recorded requests and responses, which can be replayed
+// to reproduce a session without access to the api. All secrets are redacted
+// before they are recorded.
+type Cassette struct {
+	Version      int           `json:"version"`
+	Interactions []Interaction `json:"interactions"`
+}
+
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+type RecordedRequest struct {
+	Method string      `json:"method"`
+	Path   string      `json:"path"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+}
+
+type RecordedResponse struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+func (r RecordedRequest) key() string {
+	return r.Method + " " + r.Path
+}
+
+// the recorder and replayer are shared by all clients, so a session is
+// recorded or replayed completely even if multiple clients are used
+var (
+	recorder *cassetteRecorder
+	replayer *replayTransport
+)
+
+// setupCassette prepares recording or replaying the session according to the
+// record and replay settings.
+func setupCassette() error {
+	recordPath, replayPath := viper.GetString(FlagRecord), viper.GetString(FlagReplay)
+	if len(recordPath) != 0 && len(replayPath) != 0 {
+		return fmt.Errorf("the flags --%s and --%s cannot be used together", FlagRecord, FlagReplay)
+	}
+
+	recorder, replayer = nil, nil
+
+	if len(recordPath) != 0 {
+		recorder = newCassetteRecorder(recordPath)
+	}
+
+	if len(replayPath) != 0 {
+		cassette, err := readCassette(replayPath)
+		if err != nil {
+			return err
+		}
+
+		replayer = newReplayTransport(cassette)
+	}
+
+	return nil
+}
+
+func readCassette(path string) (*Cassette, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read cassette: %w", err)
+	}
+
+	cassette := &Cassette{}
+	if err := json.Unmarshal(data, cassette); err != nil {
+		return nil, fmt.Errorf("parse cassette %q: %w", path, err)
+	}
+
+	if cassette.Version != cassetteVersion {
+		return nil, fmt.Errorf("unsupported cassette version %d", cassette.Version)
+	}
+
+	return cassette, nil
+}
+
+// cassetteRecorder writes recorded interactions to the cassette file. The
+// file is rewritten after every request, so it is complete even if the
+// command fails.
+type cassetteRecorder struct {
+	path string
+
+	mutex    sync.Mutex
+	cassette Cassette
+}
+
+func newCassetteRecorder(path string) *cassetteRecorder {
+	return &cassetteRecorder{
+		path:     path,
+		cassette: Cassette{Version: cassetteVersion, Interactions: []Interaction{}},
+	}
+}
+
+func (c *cassetteRecorder) append(interaction Interaction) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.cassette.Interactions = append(c.cassette.Interactions, interaction)
+
+	data, err := json.MarshalIndent(c.cassette, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(c.path, data, 0600); err != nil {
+		return fmt.Errorf("write cassette: %w", err)
+	}
+
+	return nil
+}
+
+var _ http.RoundTripper = (*recordTransport)(nil)
+
+// recordTransport sends all requests and records them together with their
+// responses using the recorder.
+type recordTransport struct {
+	delegate http.RoundTripper
+	recorder *cassetteRecorder
+}
+
+func (r recordTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	reqBody, err := readBody(&req.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := r.base().RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resBody, err := readBody(&res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	interaction := Interaction{
+		Request: RecordedRequest{
+			Method: req.Method,
+			Path:   req.URL.RequestURI(),
+			Header: redactHeader(req.Header),
+			Body:   string(redactBody(reqBody)),
+		},
+		Response: RecordedResponse{
+			StatusCode: res.StatusCode,
+			Header:     redactHeader(res.Header),
+			Body:       string(redactBody(resBody)),
+		},
+	}
+
+	if err := r.recorder.append(interaction); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (r recordTransport) base() http.RoundTripper {
+	if r.delegate == nil {
+		return http.DefaultTransport
+	}
+
+	return r.delegate
+}
+
+var _ http.RoundTripper = (*replayTransport)(nil)
+
+// replayTransport answers requests with the responses recorded in a cassette
+// instead of sending them. Requests are matched by method, path and query.
+// Identical requests are answered with the recorded responses in order, the
+// last response is repeated once all of them have been used, e.g. when
+// polling an order.
+type replayTransport struct {
+	mutex     sync.Mutex
+	responses map[string][]RecordedResponse
+}
+
+func newReplayTransport(cassette *Cassette) *replayTransport {
+	responses := map[string][]RecordedResponse{}
+	for _, interaction := range cassette.Interactions {
+		key := interaction.Request.key()
+		responses[key] = append(responses[key], interaction.Response)
+	}
+
+	return &replayTransport{
+		responses: responses,
+	}
+}
+
+func (r *replayTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		_ = req.Body.Close()
+	}
+
+	key := RecordedRequest{Method: req.Method, Path: req.URL.RequestURI()}.key()
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	recorded := r.responses[key]
+	if len(recorded) == 0 {
+		return nil, fmt.Errorf("no recorded response for `%s`", key)
+	}
+
+	response := recorded[0]
+	if len(recorded) > 1 {
+		r.responses[key] = recorded[1:]
+	}
+
+	header := response.Header.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", response.StatusCode, http.StatusText(response.StatusCode)),
+		StatusCode:    response.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(strings.NewReader(response.Body)),
+		ContentLength: int64(len(response.Body)),
+		Request:       req,
+	}, nil
+}
@@ -0,0 +1,103 @@
+package commands
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/flowswiss/goclient"
+
+	"github.com/flowswiss/cli/v2/pkg/api/kubernetes"
+)
+
+const testKubeConfig = "apiVersion: v1\nusers:\n- name: admin\n  user:\n    token: kube-secret\n"
+
+func TestCassette_RecordReplay(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		res.Header().Set("Content-Type", "application/json")
+
+		switch req.URL.Path {
+		case "/v4/kubernetes/clusters/1":
+			_, _ = res.Write([]byte(`{"id": 1, "name": "my-cluster", "kube_config": {"updated_at": "2022-01-01T00:00:00+00:00", "expires_at": "2023-01-01T00:00:00+00:00"}}`))
+		case "/v4/kubernetes/clusters/1/kube-config":
+			_, _ = res.Write([]byte(`{"kube_config": "apiVersion: v1\nusers:\n- name: admin\n  user:\n    token: kube-secret\n"}`))
+		default:
+			res.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	ctx := context.Background()
+
+	recordClient := goclient.NewClient(
+		goclient.WithBase(server.URL),
+		goclient.WithHTTPClientOption(func(client *http.Client) {
+			client.Transport = recordTransport{recorder: newCassetteRecorder(path)}
+		}),
+		goclient.WithToken("secret-token"),
+	)
+
+	recordService := kubernetes.NewClusterService(recordClient)
+
+	recorded, err := recordService.Get(ctx, 1)
+	if err != nil {
+		t.Fatalf("get cluster while recording: %v", err)
+	}
+
+	kubeConfig, err := recordService.GetKubeConfig(ctx, 1)
+	if err != nil {
+		t.Fatalf("get kube config while recording: %v", err)
+	}
+
+	if kubeConfig.KubeConfig != testKubeConfig {
+		t.Errorf("expected recording to return the original kube config, got %q", kubeConfig.KubeConfig)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, secret := range []string{"secret-token", "kube-secret"} {
+		if strings.Contains(string(data), secret) {
+			t.Errorf("cassette contains secret %q", secret)
+		}
+	}
+
+	cassette, err := readCassette(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	replayClient := goclient.NewClient(
+		goclient.WithBase(server.URL),
+		goclient.WithHTTPClientOption(func(client *http.Client) {
+			client.Transport = newReplayTransport(cassette)
+		}),
+	)
+
+	replayService := kubernetes.NewClusterService(replayClient)
+
+	replayed, err := replayService.Get(ctx, 1)
+	if err != nil {
+		t.Fatalf("get cluster while replaying: %v", err)
+	}
+
+	if replayed.Name != recorded.Name || replayed.KubeConfig.ExpiresAt.AsTime() != recorded.KubeConfig.ExpiresAt.AsTime() {
+		t.Errorf("expected replayed cluster %+v, got %+v", recorded, replayed)
+	}
+
+	kubeConfig, err = replayService.GetKubeConfig(ctx, 1)
+	if err != nil {
+		t.Fatalf("get kube config while replaying: %v", err)
+	}
+
+	if kubeConfig.KubeConfig != redactedValue {
+		t.Errorf("expected replayed kube config to be redacted, got %q", kubeConfig.KubeConfig)
+	}
+}
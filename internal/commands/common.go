@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"regexp"
 	"strings"
+	"sync"
 
 	"github.com/spf13/cobra"
 
@@ -45,6 +46,12 @@ func FormatExamples(examples string) string {
 	return FormatAndIndent(examples, 1)
 }
 
+// AddFilterFlag adds the --filter flag to a list command. The example should
+// be a filter expression on the columns of the listed items.
+func AddFilterFlag(cmd *cobra.Command, value *string, example string) {
+	cmd.Flags().StringVar(value, "filter", "", fmt.Sprintf("custom term or expression (e.g. %s) to filter the results", example))
+}
+
 func Confirm(message string) bool {
 	return console.Confirm(Stderr, message)
 }
@@ -53,11 +60,81 @@ func ConfirmDeletion(kind string, item fmt.Stringer) bool {
 	return console.Confirm(Stderr, fmt.Sprintf("Are you sure you want to delete the %s %q?", kind, item))
 }
 
+// selectMutex prevents concurrent lookups from prompting at the same time.
+var selectMutex sync.Mutex
+
+func selectCandidate(term string, candidates []string) (int, error) {
+	selectMutex.Lock()
+	defer selectMutex.Unlock()
+
+	Stderr.Printf("The term %q matches multiple items:\n", term)
+	return console.Select(Stderr, "Select an item", candidates)
+}
+
 func WaitForOrder(ctx context.Context, action string, ordering common.Ordering) (common.Order, error) {
-	progress := console.NewProgress(action)
-	defer progress.Done()
+	orders, errs := WaitForOrders(ctx, []PendingOrder{{Action: action, Ordering: ordering}})
+	return orders[0], errs[0]
+}
+
+// PendingOrder is an order which has been placed and is waited for under the
+// description of its action.
+type PendingOrder struct {
+	Action   string
+	Ordering common.Ordering
+}
+
+// WaitForOrders waits concurrently until all orders are processed while
+// displaying the progress of each. The resulting orders and errors have the
+// same indices as the pending orders.
+func WaitForOrders(ctx context.Context, pending []PendingOrder) ([]common.Order, []error) {
+	orders := make([]common.Order, len(pending))
+	errs := make([]error, len(pending))
+
+	progress := console.NewProgress(Stderr)
+
+	tasks := make([]*console.Task, len(pending))
+	for i, p := range pending {
+		tasks[i] = progress.Add(p.Action)
+	}
 
-	go progress.Display(Stderr)
+	progress.Start()
+
+	wg := sync.WaitGroup{}
+	for i := range pending {
+		wg.Add(1)
+
+		go func(i int) {
+			defer wg.Done()
+
+			orders[i], errs[i] = waitForOrder(ctx, pending[i].Ordering)
+			tasks[i].Done(errs[i])
+		}(i)
+	}
+
+	wg.Wait()
+	progress.Stop()
+
+	return orders, errs
+}
+
+func waitForOrder(ctx context.Context, ordering common.Ordering) (common.Order, error) {
+	order, err := common.WaitForOrder(ctx, Config.Client, ordering)
+	if err != nil && ctx.Err() != nil {
+		// the order keeps being processed by the server, even though we stop waiting for it
+		id, _ := ordering.ExtractIdentifier()
+		return order, fmt.Errorf("cancelled, order %d is still processing: %w", id, ctx.Err())
+	}
+
+	return order, err
+}
+
+// PrintOrdering prints the reference of an order which has been placed
+// without waiting for it to be processed.
+func PrintOrdering(ordering common.Ordering) error {
+	ref, err := common.NewOrderReference(ordering)
+	if err != nil {
+		return err
+	}
 
-	return common.WaitForOrder(ctx, Config.Client, ordering)
+	return PrintStdout(ref)
 }
@@ -30,7 +30,10 @@ func (l *locationListCommand) Run(cmd *cobra.Command, args []string) (err error)
 	}
 
 	if len(l.filter) != 0 {
-		items = filter.Find(items, l.filter)
+		items, err = filter.Find(items, l.filter)
+		if err != nil {
+			return err
+		}
 	}
 
 	return commands.PrintStdout(items)
@@ -49,7 +52,7 @@ func (l *locationListCommand) Build(app commands.Application) *cobra.Command {
 		RunE:              l.Run,
 	}
 
-	cmd.Flags().StringVar(&l.filter, "filter", "", "custom term to filter the results")
+	commands.AddFilterFlag(cmd, &l.filter, "modules~kubernetes")
 
 	return cmd
 }
@@ -30,7 +30,10 @@ func (m *moduleListCommand) Run(cmd *cobra.Command, args []string) error {
 	}
 
 	if len(m.filter) != 0 {
-		items = filter.Find(items, m.filter)
+		items, err = filter.Find(items, m.filter)
+		if err != nil {
+			return err
+		}
 	}
 
 	return commands.PrintStdout(items)
@@ -49,7 +52,7 @@ func (m *moduleListCommand) Build(app commands.Application) *cobra.Command {
 		RunE:              m.Run,
 	}
 
-	cmd.Flags().StringVar(&m.filter, "filter", "", "custom term to filter the results")
+	commands.AddFilterFlag(cmd, &m.filter, "locations~ZRH1")
 
 	return cmd
 }
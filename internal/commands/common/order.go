@@ -0,0 +1,218 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/flowswiss/cli/v2/internal/commands"
+	"github.com/flowswiss/cli/v2/pkg/api/common"
+	"github.com/flowswiss/cli/v2/pkg/filter"
+)
+
+func Order(app commands.Application) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "order",
+		Aliases: []string{"orders"},
+		Short:   "Manage orders",
+		Example: commands.FormatExamples(fmt.Sprintf(`
+      # Create two servers without waiting for them
+      %[1]s compute server create --name web-1 --location ALP1 --image linux-ubuntu-20.04-lts --product b1.1x1 --key-pair my-keypair --no-wait
+      %[1]s compute server create --name web-2 --location ALP1 --image linux-ubuntu-20.04-lts --product b1.1x1 --key-pair my-keypair --no-wait
+      
+      # Wait until both orders are processed
+      %[1]s order wait 1234 1235
+		`, app.Name)),
+	}
+
+	commands.Add(app, cmd,
+		&orderListCommand{},
+		&orderGetCommand{},
+		&orderWaitCommand{},
+	)
+
+	return cmd
+}
+
+type orderListCommand struct {
+	filter string
+}
+
+func (o *orderListCommand) Run(cmd *cobra.Command, args []string) error {
+	items, err := common.Orders(cmd.Context(), commands.Config.Client)
+	if err != nil {
+		return fmt.Errorf("fetch orders: %w", err)
+	}
+
+	if len(o.filter) != 0 {
+		items, err = filter.Find(items, o.filter)
+		if err != nil {
+			return err
+		}
+	}
+
+	return commands.PrintStdout(items)
+}
+
+func (o *orderListCommand) CompleteArg(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return nil, cobra.ShellCompDirectiveNoFileComp
+}
+
+func (o *orderListCommand) Build(app commands.Application) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"show", "ls"},
+		Short:   "List orders",
+		Long:    "Lists all orders.",
+		Example: commands.FormatExamples(fmt.Sprintf(`
+      # List all orders which are still processing
+      %[1]s order list --filter status=processing
+		`, app.Name)),
+		ValidArgsFunction: o.CompleteArg,
+		RunE:              o.Run,
+	}
+
+	commands.AddFilterFlag(cmd, &o.filter, "status=processing")
+
+	return cmd
+}
+
+type orderGetCommand struct{}
+
+func (o *orderGetCommand) Run(cmd *cobra.Command, args []string) error {
+	results := commands.ResultPrinter[common.Order]{}
+
+	err := commands.ForEach(args, func(term string) error {
+		ref, err := common.ParseOrderReference(term)
+		if err != nil {
+			return err
+		}
+
+		order, err := common.GetOrder(cmd.Context(), commands.Config.Client, ref.ID)
+		if err != nil {
+			return fmt.Errorf("fetch order: %w", err)
+		}
+
+		return results.Add(order)
+	})
+
+	if flushErr := results.Flush(); flushErr != nil {
+		return flushErr
+	}
+
+	return err
+}
+
+func (o *orderGetCommand) CompleteArg(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return completeOrder(cmd.Context(), toComplete, nil)
+}
+
+func (o *orderGetCommand) Build(app commands.Application) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "get ORDER...",
+		Short: "Get orders",
+		Long:  "Prints the current status of orders by their id or reference.",
+		Example: commands.FormatExamples(fmt.Sprintf(`
+      # Get the status of an order
+      %[1]s order get 1234
+      
+      # Get the status of an order by its reference
+      %[1]s order get /v4/orders/1234
+		`, app.Name)),
+		Args:              cobra.MinimumNArgs(1),
+		ValidArgsFunction: o.CompleteArg,
+		RunE:              o.Run,
+	}
+
+	return cmd
+}
+
+type orderWaitCommand struct{}
+
+func (o *orderWaitCommand) Run(cmd *cobra.Command, args []string) error {
+	pending := make([]commands.PendingOrder, len(args))
+	for i, term := range args {
+		ref, err := common.ParseOrderReference(term)
+		if err != nil {
+			return err
+		}
+
+		pending[i] = commands.PendingOrder{
+			Action:   fmt.Sprintf("Waiting for order %d", ref.ID),
+			Ordering: ref.Ordering(),
+		}
+	}
+
+	orders, errs := commands.WaitForOrders(cmd.Context(), pending)
+	results := commands.ResultPrinter[common.Order]{}
+
+	idx := 0
+	err := commands.ForEach(args, func(term string) error {
+		order, err := orders[idx], errs[idx]
+		idx++
+
+		if errors.Is(err, common.ErrOrderFailed) {
+			if addErr := results.Add(order); addErr != nil {
+				return addErr
+			}
+
+			return fmt.Errorf("order %d failed", order.ID)
+		}
+
+		if err != nil {
+			return fmt.Errorf("wait for order: %w", err)
+		}
+
+		return results.Add(order)
+	})
+
+	if flushErr := results.Flush(); flushErr != nil {
+		return flushErr
+	}
+
+	return err
+}
+
+func (o *orderWaitCommand) CompleteArg(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return completeOrder(cmd.Context(), toComplete, func(order common.Order) bool {
+		return !order.Processed()
+	})
+}
+
+func (o *orderWaitCommand) Build(app commands.Application) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "wait ORDER...",
+		Short: "Wait for orders",
+		Long:  "Waits until all orders are processed and prints their final status. Fails if any of the orders failed.",
+		Example: commands.FormatExamples(fmt.Sprintf(`
+      # Wait for multiple orders with a timeout
+      %[1]s order wait 1234 1235 --timeout 30m
+		`, app.Name)),
+		Args:              cobra.MinimumNArgs(1),
+		ValidArgsFunction: o.CompleteArg,
+		RunE:              o.Run,
+	}
+
+	return cmd
+}
+
+func completeOrder(ctx context.Context, term string, orderFilter func(order common.Order) bool) ([]string, cobra.ShellCompDirective) {
+	orders, err := common.Orders(ctx, commands.Config.Client)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+
+	filtered, err := filter.FindWithCustomFilter(orders, term, orderFilter)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+
+	ids := make([]string, len(filtered))
+	for i, order := range filtered {
+		ids[i] = fmt.Sprint(order.ID)
+	}
+
+	return ids, cobra.ShellCompDirectiveNoFileComp
+}
@@ -49,7 +49,10 @@ func (p *productListCommand) Run(cmd *cobra.Command, args []string) (err error)
 	}
 
 	if len(p.filter) != 0 {
-		items = filter.Find(items, p.filter)
+		items, err = filter.Find(items, p.filter)
+		if err != nil {
+			return err
+		}
 	}
 
 	return commands.PrintStdout(items)
@@ -73,7 +76,7 @@ func (p *productListCommand) Build(app commands.Application) *cobra.Command {
 		RunE:              p.Run,
 	}
 
-	cmd.Flags().StringVar(&p.filter, "filter", "", "custom term to filter the results")
+	commands.AddFilterFlag(cmd, &p.filter, "availability~ZRH1")
 
 	return cmd
 }
@@ -89,7 +92,10 @@ func (p *productCategoryListCommand) Run(cmd *cobra.Command, args []string) erro
 	}
 
 	if len(p.filter) != 0 {
-		items = filter.Find(items, p.filter)
+		items, err = filter.Find(items, p.filter)
+		if err != nil {
+			return err
+		}
 	}
 
 	return commands.PrintStdout(items)
@@ -109,7 +115,7 @@ func (p *productCategoryListCommand) Build(app commands.Application) *cobra.Comm
 		RunE:              p.Run,
 	}
 
-	cmd.Flags().StringVar(&p.filter, "filter", "", "custom term to filter the results")
+	commands.AddFilterFlag(cmd, &p.filter, "key~compute")
 
 	return cmd
 }
@@ -120,7 +126,10 @@ func completeProductCategory(ctx context.Context, term string) ([]string, cobra.
 		return nil, cobra.ShellCompDirectiveError
 	}
 
-	filtered := filter.Find(categories, term)
+	filtered, err := filter.Find(categories, term)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
 
 	names := make([]string, len(filtered))
 	for i, category := range filtered {
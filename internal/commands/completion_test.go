@@ -0,0 +1,113 @@
+package commands
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/flowswiss/cli/v2/pkg/console"
+	"github.com/flowswiss/cli/v2/pkg/filter"
+)
+
+// newTestRoot creates a root command loading the configuration like the root
+// command of the application.
+func newTestRoot(app Application, cmds ...*cobra.Command) *cobra.Command {
+	root := &cobra.Command{
+		Use: app.Name,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			return loadConfig(app, cmd, args)
+		},
+	}
+
+	root.AddCommand(cmds...)
+	setupFlags(app, root)
+
+	return root
+}
+
+type completionItem string
+
+func (c completionItem) Keys() []string {
+	return []string{string(c)}
+}
+
+func TestCompletion_AmbiguousTerm(t *testing.T) {
+	items := []completionItem{"web-1", "web-2", "db-1"}
+
+	defer viper.Reset()
+	t.Setenv("FLOW_TOKEN", "secret")
+
+	dir := configDir
+	configDir = t.TempDir()
+	defer func() { configDir = dir }()
+
+	isTerminal := stdinIsTerminal
+	stdinIsTerminal = func() bool { return true }
+	defer func() { stdinIsTerminal = isTerminal }()
+
+	defer func() { filter.Select = nil }()
+
+	// a prompt would fail reading from the closed stdin instead of blocking
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = writer.Close()
+	defer reader.Close()
+
+	stdin := os.Stdin
+	os.Stdin = reader
+	defer func() { os.Stdin = stdin }()
+
+	output := filepath.Join(t.TempDir(), "stderr")
+	file, err := os.Create(output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	stderr := Stderr
+	Stderr = console.NewConsoleOutput(file)
+	defer func() { Stderr = stderr }()
+
+	app := Application{Name: "flow", Version: "test"}
+
+	root := newTestRoot(app, &cobra.Command{
+		Use: "get",
+		Run: func(cmd *cobra.Command, args []string) {},
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			item, err := filter.FindOne(items, toComplete)
+			if err != nil {
+				return nil, cobra.ShellCompDirectiveError
+			}
+
+			return []string{string(item)}, cobra.ShellCompDirectiveNoFileComp
+		},
+	})
+
+	var out bytes.Buffer
+	root.SetOut(&out)
+	root.SetArgs([]string{cobra.ShellCompRequestCmd, "get", "web"})
+
+	if err := root.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !strings.Contains(out.String(), ":1\n") {
+		t.Errorf("expected the completion to fail with an error directive, got %q", out.String())
+	}
+
+	prompt, err := os.ReadFile(output)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if strings.Contains(string(prompt), "matches multiple items") {
+		t.Errorf("expected no prompt during completion, got %q", prompt)
+	}
+}
@@ -40,7 +40,10 @@ func (c *certificateListCommand) Run(cmd *cobra.Command, args []string) error {
 	}
 
 	if len(c.filter) != 0 {
-		items = filter.Find(items, c.filter)
+		items, err = filter.Find(items, c.filter)
+		if err != nil {
+			return err
+		}
 	}
 
 	return commands.PrintStdout(items)
@@ -60,7 +63,7 @@ func (c *certificateListCommand) Build(app commands.Application) *cobra.Command
 		RunE:              c.Run,
 	}
 
-	cmd.Flags().StringVar(&c.filter, "filter", "", "custom term to filter the results")
+	commands.AddFilterFlag(cmd, &c.filter, "location=ALP1,name~^www")
 
 	return cmd
 }
@@ -184,7 +187,10 @@ func completeCertificate(ctx context.Context, term string) ([]string, cobra.Shel
 		return nil, cobra.ShellCompDirectiveError
 	}
 
-	filtered := filter.Find(certificates, term)
+	filtered, err := filter.Find(certificates, term)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
 
 	names := make([]string, len(filtered))
 	for i, item := range filtered {
@@ -10,6 +10,7 @@ import (
 	"github.com/flowswiss/cli/v2/pkg/api/common"
 	"github.com/flowswiss/cli/v2/pkg/api/compute"
 	"github.com/flowswiss/cli/v2/pkg/filter"
+	"github.com/flowswiss/cli/v2/pkg/resolve"
 )
 
 func ElasticIPCommand(app commands.Application) *cobra.Command {
@@ -51,7 +52,10 @@ func (e *elasticIPListCommand) Run(cmd *cobra.Command, args []string) error {
 	}
 
 	if len(e.filter) != 0 {
-		items = filter.Find(items, e.filter)
+		items, err = filter.Find(items, e.filter)
+		if err != nil {
+			return err
+		}
 	}
 
 	return commands.PrintStdout(items)
@@ -71,7 +75,7 @@ func (e *elasticIPListCommand) Build(app commands.Application) *cobra.Command {
 		RunE:              e.Run,
 	}
 
-	cmd.Flags().StringVar(&e.filter, "filter", "", "custom term to filter the results")
+	commands.AddFilterFlag(cmd, &e.filter, "location=ALP1")
 
 	return cmd
 }
@@ -195,7 +199,19 @@ type elasticIPAttachCommand struct {
 }
 
 func (e *elasticIPAttachCommand) Run(cmd *cobra.Command, args []string) error {
-	elasticIP, err := findElasticIP(cmd.Context(), args[0])
+	var elasticIP compute.ElasticIP
+	var server compute.Server
+
+	err := resolve.All(cmd.Context(),
+		func(ctx context.Context) (err error) {
+			elasticIP, err = findElasticIP(ctx, args[0])
+			return err
+		},
+		func(ctx context.Context) (err error) {
+			server, err = findServer(ctx, args[1])
+			return err
+		},
+	)
 	if err != nil {
 		return err
 	}
@@ -204,11 +220,6 @@ func (e *elasticIPAttachCommand) Run(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("elastic ip is already attached to a server")
 	}
 
-	server, err := findServer(cmd.Context(), args[1])
-	if err != nil {
-		return err
-	}
-
 	data := compute.ElasticIPAttach{
 		ElasticIPID: elasticIP.ID,
 	}
@@ -338,12 +349,15 @@ func (e *elasticIPDetachCommand) Build(app commands.Application) *cobra.Command
 }
 
 func completeElasticIP(ctx context.Context, term string, itemFilter func(ip compute.ElasticIP) bool) ([]string, cobra.ShellCompDirective) {
-	elasticIPs, err := compute.NewElasticIPService(commands.Config.Client).List(ctx)
+	elasticIPs, err := elasticIPResolver.List(ctx)
 	if err != nil {
 		return nil, cobra.ShellCompDirectiveError
 	}
 
-	filtered := filter.FindWithCustomFilter(elasticIPs, term, itemFilter)
+	filtered, err := filter.FindWithCustomFilter(elasticIPs, term, itemFilter)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
 
 	names := make([]string, len(filtered))
 	for i, ip := range filtered {
@@ -353,13 +367,20 @@ func completeElasticIP(ctx context.Context, term string, itemFilter func(ip comp
 	return names, cobra.ShellCompDirectiveNoFileComp
 }
 
-func findElasticIP(ctx context.Context, term string) (compute.ElasticIP, error) {
-	elasticIPs, err := compute.NewElasticIPService(commands.Config.Client).List(ctx)
-	if err != nil {
-		return compute.ElasticIP{}, fmt.Errorf("fetch elastic ips: %w", err)
-	}
+var elasticIPResolver = resolve.New(
+	func(ctx context.Context) ([]compute.ElasticIP, error) {
+		items, err := compute.NewElasticIPService(commands.Config.Client).List(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("fetch elastic ips: %w", err)
+		}
 
-	elasticIP, err := filter.FindOne(elasticIPs, term)
+		return items, nil
+	},
+	nil,
+)
+
+func findElasticIP(ctx context.Context, term string) (compute.ElasticIP, error) {
+	elasticIP, err := elasticIPResolver.Find(ctx, term)
 	if err != nil {
 		return compute.ElasticIP{}, fmt.Errorf("find elastic ip: %w", err)
 	}
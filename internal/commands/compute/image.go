@@ -42,7 +42,10 @@ func (i *imageListCommand) Run(cmd *cobra.Command, args []string) error {
 	})
 
 	if len(i.filter) != 0 {
-		items = filter.Find(items, i.filter)
+		items, err = filter.Find(items, i.filter)
+		if err != nil {
+			return err
+		}
 	}
 
 	return commands.PrintStdout(items)
@@ -62,7 +65,7 @@ func (i *imageListCommand) Build(app commands.Application) *cobra.Command {
 		RunE:              i.Run,
 	}
 
-	cmd.Flags().StringVar(&i.filter, "filter", "", "custom term to filter the results")
+	commands.AddFilterFlag(cmd, &i.filter, "operating-system~ubuntu")
 
 	return cmd
 }
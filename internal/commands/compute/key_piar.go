@@ -39,7 +39,10 @@ func (k *keyPairListCommand) Run(cmd *cobra.Command, args []string) error {
 	}
 
 	if len(k.filter) != 0 {
-		keyPairs = filter.Find(keyPairs, k.filter)
+		keyPairs, err = filter.Find(keyPairs, k.filter)
+		if err != nil {
+			return err
+		}
 	}
 
 	return commands.PrintStdout(keyPairs)
@@ -59,7 +62,7 @@ func (k *keyPairListCommand) Build(app commands.Application) *cobra.Command {
 		RunE:              k.Run,
 	}
 
-	cmd.Flags().StringVar(&k.filter, "filter", "", "custom term to filter the results")
+	commands.AddFilterFlag(cmd, &k.filter, "name~^deploy-")
 
 	return cmd
 }
@@ -165,7 +168,10 @@ func completeKeyPair(ctx context.Context, term string) ([]string, cobra.ShellCom
 		return nil, cobra.ShellCompDirectiveError
 	}
 
-	filtered := filter.Find(keyPairs, term)
+	filtered, err := filter.Find(keyPairs, term)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
 
 	names := make([]string, len(filtered))
 	for i, keyPair := range filtered {
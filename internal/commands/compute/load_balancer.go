@@ -48,7 +48,10 @@ func (l *loadBalancerListCommand) Run(cmd *cobra.Command, args []string) error {
 	}
 
 	if len(l.filter) != 0 {
-		items = filter.Find(items, l.filter)
+		items, err = filter.Find(items, l.filter)
+		if err != nil {
+			return err
+		}
 	}
 
 	return commands.PrintStdout(items)
@@ -68,7 +71,7 @@ func (l *loadBalancerListCommand) Build(app commands.Application) *cobra.Command
 		RunE:              l.Run,
 	}
 
-	cmd.Flags().StringVar(&l.filter, "filter", "", "custom term to filter the results")
+	commands.AddFilterFlag(cmd, &l.filter, "location=ALP1,name~^web-")
 
 	return cmd
 }
@@ -78,6 +81,7 @@ type loadBalancerCreateCommand struct {
 	internal  bool
 	network   string
 	privateIP net.IP
+	noWait    bool
 }
 
 func (l *loadBalancerCreateCommand) Run(cmd *cobra.Command, args []string) error {
@@ -104,6 +108,10 @@ func (l *loadBalancerCreateCommand) Run(cmd *cobra.Command, args []string) error
 		return fmt.Errorf("create load balancer: %w", err)
 	}
 
+	if l.noWait {
+		return commands.PrintOrdering(ordering)
+	}
+
 	order, err := commands.WaitForOrder(cmd.Context(), "Creating load balancer", ordering)
 	if err != nil {
 		return fmt.Errorf("wait for order: %w", err)
@@ -134,6 +142,7 @@ func (l *loadBalancerCreateCommand) Build(app commands.Application) *cobra.Comma
 	cmd.Flags().BoolVar(&l.internal, "internal", false, "do not attach a public elastic ip to the load balancer")
 	cmd.Flags().StringVar(&l.network, "network", "", "network to create the load balancer in")
 	cmd.Flags().IPVar(&l.privateIP, "private-ip", net.IP{}, "private ip of the load balancer within the network")
+	cmd.Flags().BoolVar(&l.noWait, "no-wait", false, "print the order and return without waiting until the load balancer is ready")
 
 	_ = cmd.MarkFlagRequired("name")
 	_ = cmd.MarkFlagRequired("location")
@@ -243,7 +252,10 @@ func (l *loadBalancerProtocolListCommand) Run(cmd *cobra.Command, args []string)
 	}
 
 	if len(l.filter) != 0 {
-		items = filter.Find(items, l.filter)
+		items, err = filter.Find(items, l.filter)
+		if err != nil {
+			return err
+		}
 	}
 
 	return commands.PrintStdout(items)
@@ -263,7 +275,7 @@ func (l *loadBalancerProtocolListCommand) Build(app commands.Application) *cobra
 		RunE:              l.Run,
 	}
 
-	cmd.Flags().StringVar(&l.filter, "filter", "", "custom term to filter the results")
+	commands.AddFilterFlag(cmd, &l.filter, "name~http")
 
 	return cmd
 }
@@ -279,7 +291,10 @@ func (l *loadBalancerAlgorithmListCommand) Run(cmd *cobra.Command, args []string
 	}
 
 	if len(l.filter) != 0 {
-		items = filter.Find(items, l.filter)
+		items, err = filter.Find(items, l.filter)
+		if err != nil {
+			return err
+		}
 	}
 
 	return commands.PrintStdout(items)
@@ -299,7 +314,7 @@ func (l *loadBalancerAlgorithmListCommand) Build(app commands.Application) *cobr
 		RunE:              l.Run,
 	}
 
-	cmd.Flags().StringVar(&l.filter, "filter", "", "custom term to filter the results")
+	commands.AddFilterFlag(cmd, &l.filter, "name~robin")
 
 	return cmd
 }
@@ -315,7 +330,10 @@ func (l *loadBalancerHealthCheckTypeListCommand) Run(cmd *cobra.Command, args []
 	}
 
 	if len(l.filter) != 0 {
-		items = filter.Find(items, l.filter)
+		items, err = filter.Find(items, l.filter)
+		if err != nil {
+			return err
+		}
 	}
 
 	return commands.PrintStdout(items)
@@ -335,7 +353,7 @@ func (l *loadBalancerHealthCheckTypeListCommand) Build(app commands.Application)
 		RunE:              l.Run,
 	}
 
-	cmd.Flags().StringVar(&l.filter, "filter", "", "custom term to filter the results")
+	commands.AddFilterFlag(cmd, &l.filter, "name~http")
 
 	return cmd
 }
@@ -346,7 +364,10 @@ func completeLoadBalancer(ctx context.Context, term string) ([]string, cobra.She
 		return nil, cobra.ShellCompDirectiveError
 	}
 
-	filtered := filter.Find(loadBalancers, term)
+	filtered, err := filter.Find(loadBalancers, term)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
 
 	names := make([]string, len(filtered))
 	for i, loadBalancer := range filtered {
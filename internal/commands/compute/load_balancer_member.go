@@ -51,7 +51,10 @@ func (l *loadBalancerMemberListCommand) Run(cmd *cobra.Command, args []string) e
 	}
 
 	if len(l.filter) != 0 {
-		items = filter.Find(items, l.filter)
+		items, err = filter.Find(items, l.filter)
+		if err != nil {
+			return err
+		}
 	}
 
 	return commands.PrintStdout(items)
@@ -85,7 +88,7 @@ func (l *loadBalancerMemberListCommand) Build(app commands.Application) *cobra.C
 		RunE:              l.Run,
 	}
 
-	cmd.Flags().StringVar(&l.filter, "filter", "", "custom term to filter the results")
+	commands.AddFilterFlag(cmd, &l.filter, "address~^10.0.")
 
 	return cmd
 }
@@ -253,7 +256,10 @@ func completeLoadBalancerMember(ctx context.Context, loadBalancer compute.LoadBa
 		return nil, cobra.ShellCompDirectiveError
 	}
 
-	filtered := filter.Find(members, term)
+	filtered, err := filter.Find(members, term)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
 
 	names := make([]string, len(filtered))
 	for i, member := range filtered {
@@ -45,7 +45,10 @@ func (l *loadBalancerPoolListCommand) Run(cmd *cobra.Command, args []string) err
 	}
 
 	if len(l.filter) != 0 {
-		items = filter.Find(items, l.filter)
+		items, err = filter.Find(items, l.filter)
+		if err != nil {
+			return err
+		}
 	}
 
 	return commands.PrintStdout(items)
@@ -70,7 +73,7 @@ func (l *loadBalancerPoolListCommand) Build(app commands.Application) *cobra.Com
 		RunE:              l.Run,
 	}
 
-	cmd.Flags().StringVar(&l.filter, "filter", "", "custom term to filter the results")
+	commands.AddFilterFlag(cmd, &l.filter, "entry-port=443")
 
 	return cmd
 }
@@ -398,7 +401,10 @@ func completeLoadBalancerPool(ctx context.Context, loadBalancer compute.LoadBala
 		return nil, cobra.ShellCompDirectiveError
 	}
 
-	filtered := filter.Find(loadBalancerPools, term)
+	filtered, err := filter.Find(loadBalancerPools, term)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
 
 	names := make([]string, len(filtered))
 	for i, loadBalancerPool := range filtered {
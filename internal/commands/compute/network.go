@@ -48,7 +48,10 @@ func (n *networkListCommand) Run(cmd *cobra.Command, args []string) error {
 	}
 
 	if len(n.filter) != 0 {
-		items = filter.Find(items, n.filter)
+		items, err = filter.Find(items, n.filter)
+		if err != nil {
+			return err
+		}
 	}
 
 	return commands.PrintStdout(items)
@@ -68,7 +71,7 @@ func (n *networkListCommand) Build(app commands.Application) *cobra.Command {
 		RunE:              n.Run,
 	}
 
-	cmd.Flags().StringVar(&n.filter, "filter", "", "custom term to filter the results")
+	commands.AddFilterFlag(cmd, &n.filter, "location=ALP1")
 
 	return cmd
 }
@@ -342,7 +345,10 @@ func completeNetwork(ctx context.Context, term string) ([]string, cobra.ShellCom
 		return nil, cobra.ShellCompDirectiveError
 	}
 
-	filtered := filter.Find(networks, term)
+	filtered, err := filter.Find(networks, term)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
 
 	names := make([]string, len(filtered))
 	for i, network := range filtered {
@@ -47,7 +47,10 @@ func (n *networkInterfaceListCommand) Run(cmd *cobra.Command, args []string) err
 	}
 
 	if len(n.filter) != 0 {
-		items = filter.Find(items, n.filter)
+		items, err = filter.Find(items, n.filter)
+		if err != nil {
+			return err
+		}
 	}
 
 	return commands.PrintStdout(items)
@@ -72,7 +75,7 @@ func (n networkInterfaceListCommand) Build(app commands.Application) *cobra.Comm
 		RunE:              n.Run,
 	}
 
-	cmd.Flags().StringVar(&n.filter, "filter", "", "custom term to filter the results")
+	commands.AddFilterFlag(cmd, &n.filter, "network=my-network")
 
 	return cmd
 }
@@ -312,7 +315,10 @@ func completeNetworkInterface(ctx context.Context, server compute.Server, term s
 		return nil, cobra.ShellCompDirectiveError
 	}
 
-	filtered := filter.Find(interfaces, term)
+	filtered, err := filter.Find(interfaces, term)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
 
 	names := make([]string, len(filtered))
 	for i, iface := range filtered {
@@ -48,7 +48,10 @@ func (r *routerListCommand) Run(cmd *cobra.Command, args []string) error {
 	}
 
 	if len(r.filter) != 0 {
-		items = filter.Find(items, r.filter)
+		items, err = filter.Find(items, r.filter)
+		if err != nil {
+			return err
+		}
 	}
 
 	return commands.PrintStdout(items)
@@ -68,7 +71,7 @@ func (r *routerListCommand) Build(app commands.Application) *cobra.Command {
 		RunE:              r.Run,
 	}
 
-	cmd.Flags().StringVar(&r.filter, "filter", "", "custom term to filter the results")
+	commands.AddFilterFlag(cmd, &r.filter, "location=ALP1")
 
 	return cmd
 }
@@ -239,7 +242,10 @@ func completeRouter(ctx context.Context, term string) ([]string, cobra.ShellComp
 		return nil, cobra.ShellCompDirectiveError
 	}
 
-	filtered := filter.Find(routers, term)
+	filtered, err := filter.Find(routers, term)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
 
 	names := make([]string, len(filtered))
 	for i, router := range filtered {
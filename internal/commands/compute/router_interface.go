@@ -43,7 +43,10 @@ func (r *routerInterfaceListCommand) Run(cmd *cobra.Command, args []string) erro
 	}
 
 	if len(r.filter) != 0 {
-		items = filter.Find(items, r.filter)
+		items, err = filter.Find(items, r.filter)
+		if err != nil {
+			return err
+		}
 	}
 
 	return commands.PrintStdout(items)
@@ -68,7 +71,7 @@ func (r *routerInterfaceListCommand) Build(app commands.Application) *cobra.Comm
 		RunE:              r.Run,
 	}
 
-	cmd.Flags().StringVar(&r.filter, "filter", "", "custom term to filter the results")
+	commands.AddFilterFlag(cmd, &r.filter, "network=my-network")
 
 	return cmd
 }
@@ -211,7 +214,10 @@ func completeRouterInterface(ctx context.Context, router compute.Router, term st
 		return nil, cobra.ShellCompDirectiveError
 	}
 
-	filtered := filter.Find(interfaces, term)
+	filtered, err := filter.Find(interfaces, term)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
 
 	names := make([]string, len(filtered))
 	for i, item := range filtered {
@@ -43,7 +43,10 @@ func (r *routeListCommand) Run(cmd *cobra.Command, args []string) error {
 	}
 
 	if len(r.filter) != 0 {
-		items = filter.Find(items, r.filter)
+		items, err = filter.Find(items, r.filter)
+		if err != nil {
+			return err
+		}
 	}
 
 	return commands.PrintStdout(items)
@@ -68,7 +71,7 @@ func (r *routeListCommand) Build(app commands.Application) *cobra.Command {
 		RunE:              r.Run,
 	}
 
-	cmd.Flags().StringVar(&r.filter, "filter", "", "custom term to filter the results")
+	commands.AddFilterFlag(cmd, &r.filter, "next-hop=10.0.0.1")
 
 	return cmd
 }
@@ -195,7 +198,10 @@ func completeRouterRoute(ctx context.Context, router compute.Router, term string
 		return nil, cobra.ShellCompDirectiveError
 	}
 
-	filtered := filter.Find(routes, term)
+	filtered, err := filter.Find(routes, term)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
 
 	names := make([]string, len(filtered))
 	for i, route := range filtered {
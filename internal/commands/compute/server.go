@@ -3,6 +3,7 @@ package compute
 import (
 	"context"
 	"encoding/base64"
+	"errors"
 	"fmt"
 	"net"
 	"os"
@@ -16,6 +17,7 @@ import (
 	"github.com/flowswiss/cli/v2/pkg/api/compute"
 	"github.com/flowswiss/cli/v2/pkg/console"
 	"github.com/flowswiss/cli/v2/pkg/filter"
+	"github.com/flowswiss/cli/v2/pkg/resolve"
 )
 
 func ServerCommand(app commands.Application) *cobra.Command {
@@ -65,7 +67,10 @@ func (s *serverListCommand) Run(cmd *cobra.Command, args []string) error {
 	}
 
 	if len(s.filter) != 0 {
-		items = filter.Find(items, s.filter)
+		items, err = filter.Find(items, s.filter)
+		if err != nil {
+			return err
+		}
 	}
 
 	return commands.PrintStdout(items)
@@ -85,7 +90,7 @@ func (s *serverListCommand) Build(app commands.Application) *cobra.Command {
 		RunE:              s.Run,
 	}
 
-	cmd.Flags().StringVar(&s.filter, "filter", "", "") // TODO
+	commands.AddFilterFlag(cmd, &s.filter, "status=running,name~^web-")
 
 	return cmd
 }
@@ -101,6 +106,7 @@ type serverCreateCommand struct {
 	password         string
 	cloudInitFile    string
 	attachExternalIP bool
+	noWait           bool
 }
 
 func (s *serverCreateCommand) Run(cmd *cobra.Command, args []string) error {
@@ -229,6 +235,10 @@ func (s *serverCreateCommand) Run(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("create server: %w", err)
 	}
 
+	if s.noWait {
+		return commands.PrintOrdering(ordering)
+	}
+
 	order, err := commands.WaitForOrder(cmd.Context(), "Creating server", ordering)
 	if err != nil {
 		return fmt.Errorf("wait for order: %w", err)
@@ -272,6 +282,7 @@ func (s *serverCreateCommand) Build(app commands.Application) *cobra.Command {
 	cmd.Flags().StringVar(&s.password, "windows-password", "", "password for the windows admin user  (required if image is windows)")
 	cmd.Flags().StringVar(&s.cloudInitFile, "cloud-init", "", "cloud init script to customize creation of the server")
 	cmd.Flags().BoolVar(&s.attachExternalIP, "attach-external-ip", true, "whether to attach an elastic ip to the server")
+	cmd.Flags().BoolVar(&s.noWait, "no-wait", false, "print the order and return without waiting until the server is ready")
 
 	_ = cmd.MarkFlagRequired("name")
 	_ = cmd.MarkFlagRequired("location")
@@ -329,6 +340,7 @@ func (s *serverUpdateCommand) Build(app commands.Application) *cobra.Command {
 
 type serverUpgradeCommand struct {
 	product string
+	noWait  bool
 }
 
 func (s *serverUpgradeCommand) Run(cmd *cobra.Command, args []string) error {
@@ -358,6 +370,10 @@ func (s *serverUpgradeCommand) Run(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("upgrade server: %w", err)
 	}
 
+	if s.noWait {
+		return commands.PrintOrdering(ordering)
+	}
+
 	order, err := commands.WaitForOrder(cmd.Context(), "Upgrading server", ordering)
 	if err != nil {
 		return fmt.Errorf("wait for order: %w", err)
@@ -390,6 +406,7 @@ func (s *serverUpgradeCommand) Build(app commands.Application) *cobra.Command {
 	}
 
 	cmd.Flags().StringVar(&s.product, "product", "", "product to use for the new server")
+	cmd.Flags().BoolVar(&s.noWait, "no-wait", false, "print the order and return without waiting until the server is upgraded")
 
 	_ = cmd.MarkFlagRequired("product")
 
@@ -402,45 +419,73 @@ type serverDeleteCommand struct {
 }
 
 func (s *serverDeleteCommand) Run(cmd *cobra.Command, args []string) error {
-	server, err := findServer(cmd.Context(), args[0])
-	if err != nil {
-		return err
-	}
+	results := commands.ResultPrinter[commands.DeleteResult]{}
+
+	err := commands.ForEach(args, func(term string) error {
+		server, err := findServer(cmd.Context(), term)
+		if err != nil {
+			return err
+		}
+
+		if !s.force && !commands.ConfirmDeletion("server", server) {
+			commands.Stderr.Println("aborted.")
+			return nil
+		}
+
+		result := commands.DeleteResult{Kind: "server", ID: server.ID, Name: server.Name, Deleted: true}
+
+		err = compute.NewServerService(commands.Config.Client).Delete(cmd.Context(), server.ID, !s.detachOnly)
+		if errors.Is(err, commands.ErrDryRun) {
+			// the planned deletion is not a result
+			return err
+		}
+
+		if err != nil {
+			result.Deleted = false
+			result.Error = err.Error()
+		}
+
+		if printErr := results.Add(result); printErr != nil {
+			return printErr
+		}
+
+		if err != nil {
+			return fmt.Errorf("delete server: %w", err)
+		}
 
-	if !s.force && !commands.ConfirmDeletion("server", server) {
-		commands.Stderr.Println("aborted.")
 		return nil
-	}
+	})
 
-	err = compute.NewServerService(commands.Config.Client).Delete(cmd.Context(), server.ID, !s.detachOnly)
-	if err != nil {
-		return fmt.Errorf("delete server: %w", err)
+	// deletions do not print anything in table output
+	if commands.StructuredOutput() {
+		if flushErr := results.Flush(); flushErr != nil {
+			return flushErr
+		}
 	}
 
-	return nil
+	return err
 }
 
 func (s *serverDeleteCommand) CompleteArg(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
-	if len(args) == 0 {
-		return completeServer(cmd.Context(), toComplete)
-	}
-
-	return nil, cobra.ShellCompDirectiveNoFileComp
+	return completeServer(cmd.Context(), toComplete)
 }
 
 func (s *serverDeleteCommand) Build(app commands.Application) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "delete SERVER",
+		Use:   "delete SERVER...",
 		Short: "Delete server",
-		Long:  "Deletes a compute server.",
+		Long:  "Deletes one or more compute servers.",
 		Example: commands.FormatExamples(fmt.Sprintf(`
       # Delete a server and elastic ips attached to it
       %[1]s compute server delete my-server
       
       # Delete a server, but keep elastic ips
       %[1]s compute server delete my-server --detach-only
+      
+      # Delete multiple servers and report the result of each deletion as json
+      %[1]s compute server delete web-1 web-2 web-3 --force -o ndjson
 		`, app.Name)),
-		Args:              cobra.ExactArgs(1),
+		Args:              cobra.MinimumNArgs(1),
 		ValidArgsFunction: s.CompleteArg,
 		RunE:              s.Run,
 	}
@@ -452,12 +497,15 @@ func (s *serverDeleteCommand) Build(app commands.Application) *cobra.Command {
 }
 
 func completeServer(ctx context.Context, term string) ([]string, cobra.ShellCompDirective) {
-	servers, err := compute.NewServerService(commands.Config.Client).List(ctx)
+	servers, err := serverResolver.List(ctx)
 	if err != nil {
 		return nil, cobra.ShellCompDirectiveError
 	}
 
-	filtered := filter.Find(servers, term)
+	filtered, err := filter.Find(servers, term)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
 
 	names := make([]string, len(filtered))
 	for i, server := range filtered {
@@ -467,13 +515,22 @@ func completeServer(ctx context.Context, term string) ([]string, cobra.ShellComp
 	return names, cobra.ShellCompDirectiveNoFileComp
 }
 
-func findServer(ctx context.Context, term string) (compute.Server, error) {
-	servers, err := compute.NewServerService(commands.Config.Client).List(ctx)
-	if err != nil {
-		return compute.Server{}, fmt.Errorf("fetch servers: %w", err)
-	}
+var serverResolver = resolve.New(
+	func(ctx context.Context) ([]compute.Server, error) {
+		items, err := compute.NewServerService(commands.Config.Client).List(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("fetch servers: %w", err)
+		}
 
-	server, err := filter.FindOne(servers, term)
+		return items, nil
+	},
+	func(ctx context.Context, id int) (compute.Server, error) {
+		return compute.NewServerService(commands.Config.Client).Get(ctx, id)
+	},
+)
+
+func findServer(ctx context.Context, term string) (compute.Server, error) {
+	server, err := serverResolver.Find(ctx, term)
 	if err != nil {
 		return compute.Server{}, fmt.Errorf("find server: %w", err)
 	}
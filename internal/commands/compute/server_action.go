@@ -71,7 +71,12 @@ type serverActionRunCommand struct {
 }
 
 func (s *serverActionRunCommand) Run(cmd *cobra.Command, args []string) error {
-	return runAction(cmd.Context(), args[0], args[1])
+	server, err := runAction(cmd.Context(), args[0], args[1])
+	if err != nil {
+		return err
+	}
+
+	return commands.PrintStdout(server)
 }
 
 func (s *serverActionRunCommand) CompleteArg(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
@@ -117,27 +122,38 @@ func (s *serverActionRunCommand) Build(app commands.Application) *cobra.Command
 type serverActionRunCommandPreset string
 
 func (s serverActionRunCommandPreset) Run(cmd *cobra.Command, args []string) error {
-	return runAction(cmd.Context(), args[0], string(s))
-}
+	results := commands.ResultPrinter[compute.Server]{}
 
-func (s *serverActionRunCommandPreset) CompleteArg(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
-	if len(args) == 0 {
-		return completeServer(cmd.Context(), toComplete)
+	err := commands.ForEach(args, func(term string) error {
+		server, err := runAction(cmd.Context(), term, string(s))
+		if err != nil {
+			return err
+		}
+
+		return results.Add(server)
+	})
+
+	if flushErr := results.Flush(); flushErr != nil {
+		return flushErr
 	}
 
-	return nil, cobra.ShellCompDirectiveNoFileComp
+	return err
+}
+
+func (s *serverActionRunCommandPreset) CompleteArg(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return completeServer(cmd.Context(), toComplete)
 }
 
 func (s serverActionRunCommandPreset) Build(app commands.Application) *cobra.Command {
 	return &cobra.Command{
-		Use:   string(s) + " SERVER",
+		Use:   string(s) + " SERVER...",
 		Short: "Run " + string(s) + " action on the server",
 		Long: commands.FormatHelp(fmt.Sprintf(`
-			Runs the %[2]s action on the specified server.
+			Runs the %[2]s action on the specified servers.
 
 			This is a shortcut for "%[1]s compute server action run SERVER %[2]s".
 		`, app.Name, string(s))),
-		Args:              cobra.ExactArgs(1),
+		Args:              cobra.MinimumNArgs(1),
 		ValidArgsFunction: s.CompleteArg,
 		RunE:              s.Run,
 	}
@@ -149,7 +165,10 @@ func completeServerAction(ctx context.Context, server compute.Server, term strin
 		actions[i] = compute.ServerAction(action)
 	}
 
-	filtered := filter.Find(actions, term)
+	filtered, err := filter.Find(actions, term)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
 
 	names := make([]string, len(filtered))
 	for i, action := range filtered {
@@ -159,10 +178,10 @@ func completeServerAction(ctx context.Context, server compute.Server, term strin
 	return names, cobra.ShellCompDirectiveNoFileComp
 }
 
-func runAction(ctx context.Context, serverTerm, actionTerm string) error {
+func runAction(ctx context.Context, serverTerm, actionTerm string) (compute.Server, error) {
 	server, err := findServer(ctx, serverTerm)
 	if err != nil {
-		return err
+		return compute.Server{}, err
 	}
 
 	availableActions := make([]compute.ServerAction, len(server.Status.Actions))
@@ -172,7 +191,7 @@ func runAction(ctx context.Context, serverTerm, actionTerm string) error {
 
 	action, err := filter.FindOne(availableActions, actionTerm)
 	if err != nil {
-		return fmt.Errorf("the selected action does not exist or is currently not possible")
+		return compute.Server{}, fmt.Errorf("the selected action does not exist or is currently not possible")
 	}
 
 	body := compute.ServerRunAction{
@@ -181,8 +200,8 @@ func runAction(ctx context.Context, serverTerm, actionTerm string) error {
 
 	server, err = compute.NewServerActionService(commands.Config.Client).Run(ctx, server.ID, body)
 	if err != nil {
-		return fmt.Errorf("run action: %w", err)
+		return compute.Server{}, fmt.Errorf("run action: %w", err)
 	}
 
-	return commands.PrintStdout(server)
+	return server, nil
 }
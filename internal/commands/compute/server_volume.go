@@ -51,7 +51,10 @@ func (s *serverVolumeListCommand) Run(cmd *cobra.Command, args []string) error {
 	}
 
 	if len(s.filter) != 0 {
-		volumes = filter.Find(volumes, s.filter)
+		volumes, err = filter.Find(volumes, s.filter)
+		if err != nil {
+			return err
+		}
 	}
 
 	return commands.PrintStdout(volumes)
@@ -76,7 +79,7 @@ func (s *serverVolumeListCommand) Build(app commands.Application) *cobra.Command
 		RunE:              s.Run,
 	}
 
-	cmd.Flags().StringVar(&s.filter, "filter", "", "custom term to filter the results")
+	commands.AddFilterFlag(cmd, &s.filter, "name~^data-")
 
 	return cmd
 }
@@ -39,7 +39,10 @@ func (s *snapshotListCommand) Run(cmd *cobra.Command, args []string) error {
 	}
 
 	if len(s.filter) != 0 {
-		snapshots = filter.Find(snapshots, s.filter)
+		snapshots, err = filter.Find(snapshots, s.filter)
+		if err != nil {
+			return err
+		}
 	}
 
 	return commands.PrintStdout(snapshots)
@@ -59,7 +62,7 @@ func (s *snapshotListCommand) Build(app commands.Application) *cobra.Command {
 		RunE:              s.Run,
 	}
 
-	cmd.Flags().StringVar(&s.filter, "filter", "", "custom term to filter the results")
+	commands.AddFilterFlag(cmd, &s.filter, "volume~^data-")
 
 	return cmd
 }
@@ -207,7 +210,10 @@ func completeSnapshot(ctx context.Context, term string) ([]string, cobra.ShellCo
 		return nil, cobra.ShellCompDirectiveError
 	}
 
-	filtered := filter.Find(snapshots, term)
+	filtered, err := filter.Find(snapshots, term)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
 
 	names := make([]string, len(filtered))
 	for i, snapshot := range filtered {
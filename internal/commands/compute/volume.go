@@ -10,6 +10,7 @@ import (
 	"github.com/flowswiss/cli/v2/pkg/api/common"
 	"github.com/flowswiss/cli/v2/pkg/api/compute"
 	"github.com/flowswiss/cli/v2/pkg/filter"
+	"github.com/flowswiss/cli/v2/pkg/resolve"
 )
 
 func VolumeCommand(app commands.Application) *cobra.Command {
@@ -43,7 +44,10 @@ func (v *volumeListCommand) Run(cmd *cobra.Command, args []string) error {
 	}
 
 	if len(v.filter) != 0 {
-		volumes = filter.Find(volumes, v.filter)
+		volumes, err = filter.Find(volumes, v.filter)
+		if err != nil {
+			return err
+		}
 	}
 
 	return commands.PrintStdout(volumes)
@@ -63,7 +67,7 @@ func (v *volumeListCommand) Build(app commands.Application) *cobra.Command {
 		RunE:              v.Run,
 	}
 
-	cmd.Flags().StringVar(&v.filter, "filter", "", "custom term to filter the results")
+	commands.AddFilterFlag(cmd, &v.filter, "location=ALP1,name~^data-")
 
 	return cmd
 }
@@ -162,12 +166,19 @@ type volumeAttachCommand struct {
 }
 
 func (v *volumeAttachCommand) Run(cmd *cobra.Command, args []string) error {
-	volume, err := findVolume(cmd.Context(), args[0])
-	if err != nil {
-		return err
-	}
+	var volume compute.Volume
+	var server compute.Server
 
-	server, err := findServer(cmd.Context(), args[1])
+	err := resolve.All(cmd.Context(),
+		func(ctx context.Context) (err error) {
+			volume, err = findVolume(ctx, args[0])
+			return err
+		},
+		func(ctx context.Context) (err error) {
+			server, err = findServer(ctx, args[1])
+			return err
+		},
+	)
 	if err != nil {
 		return err
 	}
@@ -427,12 +438,15 @@ func (v *volumeDeleteCommand) Build(app commands.Application) *cobra.Command {
 }
 
 func completeVolume(ctx context.Context, term string, itemFilter func(volume compute.Volume) bool) ([]string, cobra.ShellCompDirective) {
-	volumes, err := compute.NewVolumeService(commands.Config.Client).List(ctx)
+	volumes, err := volumeResolver.List(ctx)
 	if err != nil {
 		return nil, cobra.ShellCompDirectiveError
 	}
 
-	filtered := filter.FindWithCustomFilter(volumes, term, itemFilter)
+	filtered, err := filter.FindWithCustomFilter(volumes, term, itemFilter)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
 
 	names := make([]string, len(filtered))
 	for i, volume := range filtered {
@@ -448,9 +462,12 @@ func completeVolumeSnapshot(ctx context.Context, volume compute.Volume, term str
 		return nil, cobra.ShellCompDirectiveError
 	}
 
-	filtered := filter.FindWithCustomFilter(snapshots, term, func(snapshot compute.Snapshot) bool {
+	filtered, err := filter.FindWithCustomFilter(snapshots, term, func(snapshot compute.Snapshot) bool {
 		return snapshot.Volume.ID == volume.ID
 	})
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
 
 	names := make([]string, len(filtered))
 	for i, snapshot := range filtered {
@@ -460,13 +477,20 @@ func completeVolumeSnapshot(ctx context.Context, volume compute.Volume, term str
 	return names, cobra.ShellCompDirectiveNoFileComp
 }
 
-func findVolume(ctx context.Context, term string) (compute.Volume, error) {
-	volumes, err := compute.NewVolumeService(commands.Config.Client).List(ctx)
-	if err != nil {
-		return compute.Volume{}, fmt.Errorf("fetch volumes: %w", err)
-	}
+var volumeResolver = resolve.New(
+	func(ctx context.Context) ([]compute.Volume, error) {
+		items, err := compute.NewVolumeService(commands.Config.Client).List(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("fetch volumes: %w", err)
+		}
 
-	volume, err := filter.FindOne(volumes, term)
+		return items, nil
+	},
+	nil,
+)
+
+func findVolume(ctx context.Context, term string) (compute.Volume, error) {
+	volume, err := volumeResolver.Find(ctx, term)
 	if err != nil {
 		return compute.Volume{}, fmt.Errorf("find volume: %w", err)
 	}
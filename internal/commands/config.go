@@ -1,12 +1,13 @@
 package commands
 
 import (
-	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
 	"os"
 	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/flowswiss/goclient"
 	"github.com/spf13/cobra"
@@ -14,23 +15,60 @@ import (
 	"github.com/spf13/viper"
 	"golang.org/x/term"
 
-	"github.com/flowswiss/cli/v2/pkg/console"
+	"github.com/flowswiss/cli/v2/pkg/filter"
 )
 
 const (
-	FlagEndpoint = "endpoint"
-	FlagToken    = "token"
-	FlagDump     = "dump"
-	FlagDryRun   = "dry-run"
-	FlagFormat   = "format"
+	FlagEndpoint           = "endpoint"
+	FlagToken              = "token"
+	FlagTokenFile          = "token-file"
+	FlagTokenCommand       = "token-command"
+	FlagDump               = "dump"
+	FlagDumpUnredacted     = "dump-unredacted"
+	FlagRecord             = "record"
+	FlagHTTPSProxy         = "https-proxy"
+	FlagNoProxy            = "no-proxy"
+	FlagCAFile             = "ca-file"
+	FlagInsecureSkipVerify = "insecure-skip-verify"
+	FlagClientCert         = "client-cert"
+	FlagClientKey          = "client-key"
+	FlagReplay             = "replay"
+	FlagDryRun             = "dry-run"
+	FlagFormat             = "format"
+	FlagProfile            = "profile"
+	FlagColumns            = "columns"
+	FlagNoHeaders          = "no-headers"
+	FlagSortBy             = "sort-by"
+	FlagReverse            = "reverse"
+	FlagNoTruncate         = "no-truncate"
+	FlagExact              = "exact"
+	FlagNonInteractive     = "non-interactive"
+	FlagNoCache            = "no-cache"
+	FlagCacheTTL           = "cache-ttl"
+	FlagMaxRetries         = "max-retries"
+	FlagVerbose            = "verbose"
+	FlagLogFile            = "log-file"
+	FlagLogFormat          = "log-format"
+	FlagTimeout            = "timeout"
 )
 
 const (
-	FormatJSON  = "json"
-	FormatTable = "table"
-	FormatCSV   = "csv"
+	KeyContexts       = "contexts"
+	KeyCurrentContext = "current-context"
+	KeyLocation       = "location"
+	KeyColumns        = "columns"
 )
 
+// ProfileKeys contains all settings which can be configured per profile.
+var ProfileKeys = []string{
+	FlagToken, FlagTokenFile, FlagTokenCommand, FlagEndpoint, KeyLocation, FlagFormat, FlagCacheTTL,
+	FlagHTTPSProxy, FlagNoProxy, FlagCAFile, FlagInsecureSkipVerify, FlagClientCert, FlagClientKey,
+}
+
+// AnnotationSkipAuthentication marks a command (and all of its sub commands)
+// as usable without a configured authentication token.
+const AnnotationSkipAuthentication = "skip-authentication"
+
 var (
 	configFile string
 	configDir  string
@@ -40,79 +78,242 @@ var (
 
 var Config config
 
+// stdinIsTerminal reports whether the standard input is connected to a
+// terminal, which allows prompting the user.
+var stdinIsTerminal = func() bool {
+	return term.IsTerminal(int(os.Stdin.Fd()))
+}
+
 type config struct {
 	Client   goclient.Client
+	Profile  string
 	Terminal bool
 }
 
-func Print(out console.Writer, val interface{}) error {
-	format := viper.GetString(FlagFormat)
-	if format == FormatJSON {
-		return json.NewEncoder(out).Encode(val)
+func loadConfig(app Application, cmd *cobra.Command, args []string) error {
+	if err := initViper(app); err != nil {
+		return err
+	}
+
+	if err := setupLogging(); err != nil {
+		return err
 	}
 
-	separator := "   "
-	pretty := true
+	authenticate := requiresAuthentication(cmd, args)
 
-	if format == FormatCSV {
-		separator = ","
-		pretty = false
+	profile, err := loadProfile()
+	if err != nil && authenticate {
+		return err
 	}
 
-	table := console.Table{}
+	if err := setupCassette(); err != nil {
+		return err
+	}
 
-	err := table.Insert(val)
+	// warnings would end up in the output of the shell completion
+	if err := setupNetwork(!isCompletion(cmd)); err != nil {
+		return err
+	}
+
+	// replaying a session must not require the credentials of the recording
+	if replayer != nil {
+		authenticate = false
+	}
+
+	cfg, err := buildConfig(app, authenticate)
 	if err != nil {
 		return err
 	}
 
-	table.Format(out, separator, pretty)
+	cfg.Profile = profile
+	Config = cfg
 
-	Stderr.Printf("Found a total of %d items\n", len(table.Rows))
+	filter.Exact = viper.GetBool(FlagExact)
+	filter.Select = nil
+
+	// shell completion must never wait for input, as the prompt is not visible
+	if cfg.Terminal && !viper.GetBool(FlagNonInteractive) && !isCompletion(cmd) {
+		filter.Select = selectCandidate
+	}
+
+	applyDefaultLocation(cmd)
 	return nil
 }
 
-func PrintStdout(val interface{}) error {
-	return Print(Stdout, val)
+// isCompletion reports whether the command is one of the hidden commands
+// called by the shell completion scripts.
+func isCompletion(cmd *cobra.Command) bool {
+	return cmd.Name() == cobra.ShellCompRequestCmd || cmd.Name() == cobra.ShellCompNoDescRequestCmd
 }
 
-func loadConfig(app Application) {
-	if err := initViper(app); err != nil {
-		Stderr.Errorf("%v\n", err)
-		os.Exit(1)
+func requiresAuthentication(cmd *cobra.Command, args []string) bool {
+	// shell completion requires authentication only if the completed command does
+	if isCompletion(cmd) {
+		if target, _, err := cmd.Root().Find(args); err == nil {
+			cmd = target
+		}
 	}
 
-	cfg, err := buildConfig(app)
-	if err != nil {
-		Stderr.Errorf("%v\n", err)
-		os.Exit(1)
+	for c := cmd; c != nil; c = c.Parent() {
+		if _, ok := c.Annotations[AnnotationSkipAuthentication]; ok {
+			return false
+		}
 	}
 
-	Config = cfg
+	return true
 }
 
-func buildConfig(app Application) (config, error) {
-	endpoint := viper.GetString(FlagEndpoint)
-	token := viper.GetString(FlagToken)
+// loadProfile merges the settings of the selected profile on top of the
+// top-level settings of the config file. The profile is selected using the
+// profile flag or falls back to the current context stored in the config. The
+// name of the selected profile is returned even if it does not exist yet.
+func loadProfile() (string, error) {
+	profile := activeProfile()
+	if len(profile) == 0 {
+		return "", nil
+	}
+
+	key := ProfileKey(profile)
+	if !viper.IsSet(key) {
+		return profile, fmt.Errorf("profile %q does not exist", profile)
+	}
+
+	settings := viper.GetStringMap(key)
 
-	if len(token) == 0 {
-		return config{}, fmt.Errorf("missing authentication token")
+	// a token source of the profile replaces all inherited ones, otherwise a
+	// top-level token would take precedence over the token file or command of
+	// the profile
+	if hasTokenSource(settings) {
+		for _, tokenKey := range tokenKeys {
+			if _, ok := settings[tokenKey]; !ok {
+				settings[tokenKey] = ""
+			}
+		}
 	}
 
+	if err := viper.MergeConfigMap(settings); err != nil {
+		return profile, fmt.Errorf("load profile %q: %w", profile, err)
+	}
+
+	return profile, nil
+}
+
+var tokenKeys = []string{FlagToken, FlagTokenFile, FlagTokenCommand}
+
+func hasTokenSource(settings map[string]interface{}) bool {
+	for _, tokenKey := range tokenKeys {
+		if value, ok := settings[tokenKey]; ok && fmt.Sprint(value) != "" {
+			return true
+		}
+	}
+
+	return false
+}
+
+func activeProfile() string {
+	if profile := viper.GetString(FlagProfile); len(profile) != 0 {
+		return profile
+	}
+
+	return viper.GetString(KeyCurrentContext)
+}
+
+func applyDefaultLocation(cmd *cobra.Command) {
+	location := viper.GetString(KeyLocation)
+	if len(location) == 0 {
+		return
+	}
+
+	flag := cmd.Flags().Lookup(KeyLocation)
+	if flag == nil || flag.Changed {
+		return
+	}
+
+	_ = cmd.Flags().Set(KeyLocation, location)
+}
+
+func buildConfig(app Application, authenticate bool) (config, error) {
+	// the token is only resolved if needed, as the token command might be slow
+	// or prompt for credentials
+	var token string
+	if authenticate {
+		var err error
+		token, err = Token()
+		if err != nil {
+			return config{}, err
+		}
+
+		if len(token) == 0 {
+			return config{}, fmt.Errorf("missing authentication token")
+		}
+	}
+
+	return config{
+		Client:   NewClient(app, token),
+		Terminal: stdinIsTerminal(),
+	}, nil
+}
+
+// NewClient creates an api client for the configured endpoint which
+// authenticates using the given token.
+func NewClient(app Application, token string) goclient.Client {
+	return newClient(app, token, true)
+}
+
+// NewUncachedClient creates an api client like NewClient, which always sends
+// its requests to the server. It is used to verify tokens, as cached responses
+// are shared between all tokens of a profile.
+func NewUncachedClient(app Application, token string) goclient.Client {
+	return newClient(app, token, false)
+}
+
+func newClient(app Application, token string, cached bool) goclient.Client {
 	opts := []goclient.Option{
-		goclient.WithBase(endpoint),
-		goclient.WithToken(token),
+		goclient.WithBase(viper.GetString(FlagEndpoint)),
 		goclient.WithUserAgent(fmt.Sprintf("%s-cli/%s", app.Name, app.Version)),
 	}
 
-	if viper.GetBool(FlagDump) {
+	// every transport wraps the previously added ones, so the first transport is
+	// the closest to the network
+
+	if networkTransport != nil {
 		opts = append(opts, goclient.WithHTTPClientOption(func(client *http.Client) {
-			client.Transport = dumpRequestTransport{
+			client.Transport = networkTransport
+		}))
+	}
+
+	if replayer != nil {
+		opts = append(opts, goclient.WithHTTPClientOption(func(client *http.Client) {
+			client.Transport = replayer
+		}))
+	}
+
+	if recorder != nil {
+		opts = append(opts, goclient.WithHTTPClientOption(func(client *http.Client) {
+			client.Transport = recordTransport{
+				delegate: client.Transport,
+				recorder: recorder,
+			}
+		}))
+	}
+
+	if Log.Enabled(LogLevelRequests) {
+		opts = append(opts, goclient.WithHTTPClientOption(func(client *http.Client) {
+			client.Transport = logRequestTransport{
 				delegate: client.Transport,
 			}
 		}))
 	}
 
+	if viper.GetBool(FlagDump) || viper.GetBool(FlagDumpUnredacted) {
+		opts = append(opts, goclient.WithHTTPClientOption(func(client *http.Client) {
+			client.Transport = dumpRequestTransport{
+				delegate:   client.Transport,
+				unredacted: viper.GetBool(FlagDumpUnredacted),
+			}
+		}))
+	}
+
 	if viper.GetBool(FlagDryRun) {
 		opts = append(opts, goclient.WithHTTPClientOption(func(client *http.Client) {
 			client.Transport = dryRunTransport{
@@ -121,21 +322,78 @@ func buildConfig(app Application) (config, error) {
 		}))
 	}
 
-	return config{
-		Client:   goclient.NewClient(opts...),
-		Terminal: term.IsTerminal(int(os.Stdin.Fd())),
-	}, nil
+	if maxRetries := viper.GetInt(FlagMaxRetries); maxRetries > 0 {
+		opts = append(opts, goclient.WithHTTPClientOption(func(client *http.Client) {
+			client.Transport = retryTransport{
+				delegate:   client.Transport,
+				maxRetries: maxRetries,
+			}
+		}))
+	}
+
+	// the token is added after the dump and dry run transports, so they show the
+	// authorization header which is actually sent. it is also added after the
+	// retry transport, as it adds the header to the request again on every call.
+	if len(token) != 0 {
+		opts = append(opts, goclient.WithToken(token))
+	}
+
+	// cached responses would be missing in recorded sessions and must not
+	// affect replayed ones
+	if cached && !viper.GetBool(FlagNoCache) && viper.GetDuration(FlagCacheTTL) > 0 && recorder == nil && replayer == nil {
+		opts = append(opts, goclient.WithHTTPClientOption(func(client *http.Client) {
+			client.Transport = cacheTransport{
+				delegate: client.Transport,
+				dir:      cacheDirFor(viper.GetString(FlagEndpoint), activeProfile()),
+				ttl:      viper.GetDuration(FlagCacheTTL),
+			}
+		}))
+	}
+
+	return goclient.NewClient(opts...)
 }
 
 func setupFlags(app Application, root *cobra.Command) {
 	baseFlagSet = pflag.NewFlagSet("base", pflag.ContinueOnError)
 	baseFlagSet.String(FlagEndpoint, app.Endpoint, "base endpoint to use for all api requests")
 	baseFlagSet.String(FlagToken, "", "authentication token to use for all api requests")
-	baseFlagSet.Bool(FlagDump, false, "dump all requests and responses to stderr")
-	baseFlagSet.Bool(FlagDryRun, false, "dry run mode, print requests to stdout instead of sending them to the server")
-	baseFlagSet.StringP(FlagFormat, "o", "table", fmt.Sprintf("output format to use. allowed values: %s, %s or %s", FormatTable, FormatCSV, FormatJSON))
+	baseFlagSet.String(FlagTokenFile, "", "file containing the authentication token")
+	baseFlagSet.String(FlagTokenCommand, "", "command printing the authentication token to stdout")
+	baseFlagSet.Bool(FlagDump, false, "dump all requests and responses to stderr with secrets redacted")
+	baseFlagSet.Bool(FlagDumpUnredacted, false, "dump all requests and responses to stderr including secrets like the authentication token")
+	baseFlagSet.String(FlagHTTPSProxy, "", "proxy to send all api requests through, e.g. http://proxy.example.com:3128")
+	baseFlagSet.String(FlagNoProxy, "", "comma separated list of hosts which are accessed without the proxy")
+	baseFlagSet.String(FlagCAFile, "", "file containing additional pem encoded certificate authorities to trust")
+	baseFlagSet.Bool(FlagInsecureSkipVerify, false, "disable the verification of tls certificates, this is insecure")
+	baseFlagSet.String(FlagClientCert, "", "file containing the pem encoded client certificate for tls authentication")
+	baseFlagSet.String(FlagClientKey, "", "file containing the pem encoded private key of the client certificate")
+	baseFlagSet.String(FlagRecord, "", "record all requests and responses with secrets redacted to the given cassette file")
+	baseFlagSet.String(FlagReplay, "", "answer all requests with the responses recorded in the given cassette file instead of sending them")
+	baseFlagSet.Bool(FlagDryRun, false, "dry run mode, print a plan of the modifying requests instead of sending them to the server. commands with multiple steps stop at their first modifying request")
+	baseFlagSet.StringP(FlagFormat, "o", "table", fmt.Sprintf("output format to use. allowed values: %s, %s, %s, %s, %s, %s, %s, %s=TEMPLATE, %s=FILE or %s=TEMPLATE", FormatTable, FormatWide, FormatCSV, FormatTSV, FormatJSON, FormatNDJSON, FormatYAML, FormatGoTemplate, FormatGoTemplateFile, FormatJSONPath))
+	baseFlagSet.StringSlice(FlagColumns, nil, "comma separated list of columns to display in table output")
+	baseFlagSet.Bool(FlagNoHeaders, false, "do not print the column headers in table output")
+	baseFlagSet.String(FlagSortBy, "", "column by which lists are sorted")
+	baseFlagSet.Bool(FlagReverse, false, "reverse the sort order of lists")
+	baseFlagSet.Bool(FlagNoTruncate, false, "do not truncate table columns to fit the terminal width")
+	baseFlagSet.Bool(FlagExact, false, "only resolve resources by their exact id or name, disabling partial matches")
+	baseFlagSet.Bool(FlagNonInteractive, false, "never prompt for input, e.g. to select one of multiple matching resources")
+	baseFlagSet.CountP(FlagVerbose, "v", "log requests to stderr, repeat for more details (e.g. -vv)")
+	baseFlagSet.String(FlagLogFile, "", "append log messages to the file instead of stderr, implies --verbose")
+	baseFlagSet.String(FlagLogFormat, LogFormatText, fmt.Sprintf("format of log messages. allowed values: %s, %s", LogFormatText, LogFormatJSON))
+	baseFlagSet.Duration(FlagTimeout, 0, "maximum duration of the command including waiting for orders, e.g. 10m. zero disables the timeout")
+	baseFlagSet.Int(FlagMaxRetries, 3, "maximum number of retries of requests failing with a transient error")
+	baseFlagSet.Bool(FlagNoCache, false, "do not use cached catalog data like locations, products and images")
+	baseFlagSet.Duration(FlagCacheTTL, 24*time.Hour, "duration for which catalog data like locations, products and images is cached")
+	baseFlagSet.String(FlagProfile, "", "name of the configuration profile to use")
 
 	_ = baseFlagSet.MarkHidden(FlagToken)
+	_ = cobra.MarkFlagFilename(baseFlagSet, FlagTokenFile)
+	_ = cobra.MarkFlagFilename(baseFlagSet, FlagCAFile)
+	_ = cobra.MarkFlagFilename(baseFlagSet, FlagClientCert)
+	_ = cobra.MarkFlagFilename(baseFlagSet, FlagClientKey)
+	_ = cobra.MarkFlagFilename(baseFlagSet, FlagRecord, "json")
+	_ = cobra.MarkFlagFilename(baseFlagSet, FlagReplay, "json")
 
 	root.PersistentFlags().StringVar(&configFile, "config", "", fmt.Sprintf("config file (default is $HOME/.%s/config.json", app.Name))
 	root.PersistentFlags().AddFlagSet(baseFlagSet)
@@ -166,6 +424,7 @@ func initViper(app Application) error {
 	}
 
 	viper.SetEnvPrefix(app.Name)
+	viper.SetEnvKeyReplacer(strings.NewReplacer("-", "_"))
 	viper.AutomaticEnv()
 
 	if err := viper.BindPFlags(baseFlagSet); err != nil {
@@ -181,3 +440,94 @@ func initViper(app Application) error {
 
 	return nil
 }
+
+// ProfileKey returns the config key under which the settings of the given
+// profile are stored.
+func ProfileKey(profile string) string {
+	return KeyContexts + "." + profile
+}
+
+// ProfileSettingKey returns the config key of a setting within the given
+// profile. An empty profile refers to the top-level settings.
+func ProfileSettingKey(profile string, key string) string {
+	if len(profile) == 0 {
+		return key
+	}
+
+	return ProfileKey(profile) + "." + key
+}
+
+// ConfigFile returns the path of the config file in use.
+func ConfigFile() string {
+	if len(configFile) != 0 {
+		return configFile
+	}
+
+	return filepath.Join(configDir, "config.json")
+}
+
+// EditConfig reads the config file without any flags, environment variables
+// or profiles applied, passes it to edit and writes the result back to disk.
+func EditConfig(edit func(file *viper.Viper) error) error {
+	file, err := ReadConfigFile()
+	if err != nil {
+		return err
+	}
+
+	if err := edit(file); err != nil {
+		return err
+	}
+
+	return file.WriteConfig()
+}
+
+// ReadConfigFile reads the config file without any flags, environment
+// variables or profiles applied. A missing config file results in an empty
+// configuration.
+func ReadConfigFile() (*viper.Viper, error) {
+	file := newConfigFile()
+
+	if err := file.ReadInConfig(); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+
+	return file, nil
+}
+
+// UnsetConfig removes the given key from the config file.
+func UnsetConfig(key string) error {
+	file, err := ReadConfigFile()
+	if err != nil {
+		return err
+	}
+
+	settings := file.AllSettings()
+
+	path := strings.Split(strings.ToLower(key), ".")
+	parent := settings
+	for _, segment := range path[:len(path)-1] {
+		nested, ok := parent[segment].(map[string]interface{})
+		if !ok {
+			return nil
+		}
+
+		parent = nested
+	}
+
+	delete(parent, path[len(path)-1])
+
+	file = newConfigFile()
+	if err := file.MergeConfigMap(settings); err != nil {
+		return err
+	}
+
+	return file.WriteConfig()
+}
+
+func newConfigFile() *viper.Viper {
+	file := viper.New()
+	file.SetConfigPermissions(0600)
+	file.SetConfigFile(ConfigFile())
+	file.SetConfigType("json")
+	return file
+}
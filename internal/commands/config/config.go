@@ -0,0 +1,206 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/flowswiss/cli/v2/internal/commands"
+)
+
+const redacted = "REDACTED"
+
+func Module(app commands.Application) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "Manage the cli configuration",
+		Example: commands.FormatExamples(fmt.Sprintf(`
+      # Create a new profile and switch to it
+      %[1]s config set --context staging token MY_TOKEN
+      %[1]s config use-context staging
+      
+      # Show the current configuration
+      %[1]s config view
+		`, app.Name)),
+		Annotations: map[string]string{
+			commands.AnnotationSkipAuthentication: "",
+		},
+	}
+
+	commands.Add(app, cmd,
+		&configSetCommand{},
+		&configGetCommand{},
+		&configViewCommand{},
+		&contextListCommand{},
+		&contextUseCommand{},
+		&contextCurrentCommand{},
+	)
+
+	return cmd
+}
+
+type configSetCommand struct {
+	context string
+}
+
+func (c *configSetCommand) Run(cmd *cobra.Command, args []string) error {
+	key, value := strings.ToLower(args[0]), args[1]
+	if !isProfileKey(key) {
+		return fmt.Errorf("unknown setting %q, allowed settings are: %s", key, strings.Join(commands.ProfileKeys, ", "))
+	}
+
+	profile := c.context
+	if len(profile) == 0 {
+		profile = commands.Config.Profile
+	}
+
+	if strings.Contains(profile, ".") {
+		return fmt.Errorf("profile name %q must not contain a dot", profile)
+	}
+
+	return commands.EditConfig(func(file *viper.Viper) error {
+		file.Set(commands.ProfileSettingKey(profile, key), value)
+		return nil
+	})
+}
+
+func (c *configSetCommand) CompleteArg(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) == 0 {
+		return commands.ProfileKeys, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return nil, cobra.ShellCompDirectiveNoFileComp
+}
+
+func (c *configSetCommand) Build(app commands.Application) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "set KEY VALUE",
+		Short: "Set configuration value",
+		Long: commands.FormatHelp(fmt.Sprintf(`
+			Sets a value in the config file.
+
+			The value is stored in the currently active profile. If no profile is active, the value is stored globally.
+			Allowed settings are: %s.
+
+			The default table columns of a resource type can be configured using the setting "columns.<module>.<type>".
+		`, strings.Join(commands.ProfileKeys, ", "))),
+		Example: commands.FormatExamples(fmt.Sprintf(`
+      # Set the default location of the active profile
+      %[1]s config set location ALP1
+      
+      # Set the token of the production profile, creating the profile if it does not exist
+      %[1]s config set --context production token MY_TOKEN
+      
+      # Only show the id, name and public ip of compute servers by default
+      %[1]s config set columns.compute.server "id,name,public ip"
+		`, app.Name)),
+		Args:              cobra.ExactArgs(2),
+		ValidArgsFunction: c.CompleteArg,
+		RunE:              c.Run,
+	}
+
+	cmd.Flags().StringVar(&c.context, "context", "", "profile in which the value should be stored")
+	_ = cmd.RegisterFlagCompletionFunc("context", completeContext)
+
+	return cmd
+}
+
+type configGetCommand struct {
+}
+
+func (c *configGetCommand) Run(cmd *cobra.Command, args []string) error {
+	key := strings.ToLower(args[0])
+	if !isProfileKey(key) {
+		return fmt.Errorf("unknown setting %q, allowed settings are: %s", key, strings.Join(commands.ProfileKeys, ", "))
+	}
+
+	commands.Stdout.Println(viper.GetString(key))
+	return nil
+}
+
+func (c *configGetCommand) CompleteArg(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) == 0 {
+		return commands.ProfileKeys, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return nil, cobra.ShellCompDirectiveNoFileComp
+}
+
+func (c *configGetCommand) Build(app commands.Application) *cobra.Command {
+	return &cobra.Command{
+		Use:               "get KEY",
+		Short:             "Get configuration value",
+		Long:              "Prints the effective value of a setting, taking flags, environment variables and the active profile into account.",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: c.CompleteArg,
+		RunE:              c.Run,
+	}
+}
+
+type configViewCommand struct {
+	raw bool
+}
+
+func (c *configViewCommand) Run(cmd *cobra.Command, args []string) error {
+	file, err := commands.ReadConfigFile()
+	if err != nil {
+		return fmt.Errorf("read config: %w", err)
+	}
+
+	settings := file.AllSettings()
+	if !c.raw {
+		redactSecrets(settings)
+	}
+
+	encoder := json.NewEncoder(commands.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(settings)
+}
+
+func (c *configViewCommand) Build(app commands.Application) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "view",
+		Short: "Show configuration",
+		Long:  "Prints the content of the config file with all secrets redacted.",
+		Args:  cobra.NoArgs,
+		RunE:  c.Run,
+	}
+
+	cmd.Flags().BoolVar(&c.raw, "raw", false, "display secrets instead of redacting them")
+
+	return cmd
+}
+
+func redactSecrets(settings map[string]interface{}) {
+	for key, value := range settings {
+		if nested, ok := value.(map[string]interface{}); ok {
+			redactSecrets(nested)
+			continue
+		}
+
+		if key == commands.FlagToken && value != "" {
+			settings[key] = redacted
+		}
+
+		if proxy, ok := value.(string); ok && key == commands.FlagHTTPSProxy {
+			settings[key] = commands.RedactProxy(proxy)
+		}
+	}
+}
+
+func isProfileKey(key string) bool {
+	if strings.HasPrefix(key, commands.KeyColumns+".") {
+		return true
+	}
+
+	for _, k := range commands.ProfileKeys {
+		if k == key {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,178 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/flowswiss/cli/v2/internal/commands"
+	"github.com/flowswiss/cli/v2/pkg/console"
+)
+
+var _ console.Displayable = (*Context)(nil)
+
+type Context struct {
+	Name     string `json:"name"`
+	Current  bool   `json:"current"`
+	Endpoint string `json:"endpoint"`
+	Location string `json:"location"`
+	Format   string `json:"format"`
+}
+
+func (c Context) String() string {
+	return c.Name
+}
+
+func (c Context) Columns() []string {
+	return []string{"current", "name", "endpoint", "location", "format"}
+}
+
+func (c Context) Values() map[string]interface{} {
+	current := ""
+	if c.Current {
+		current = "*"
+	}
+
+	return map[string]interface{}{
+		"current":  current,
+		"name":     c.Name,
+		"endpoint": c.Endpoint,
+		"location": c.Location,
+		"format":   c.Format,
+	}
+}
+
+func contexts() ([]Context, error) {
+	file, err := commands.ReadConfigFile()
+	if err != nil {
+		return nil, fmt.Errorf("read config: %w", err)
+	}
+
+	var names []string
+	for name := range file.GetStringMap(commands.KeyContexts) {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	items := make([]Context, len(names))
+	for idx, name := range names {
+		settings := file.Sub(commands.ProfileKey(name))
+		if settings == nil {
+			settings = viper.New()
+		}
+
+		items[idx] = Context{
+			Name:     name,
+			Current:  strings.EqualFold(name, commands.Config.Profile),
+			Endpoint: settings.GetString(commands.FlagEndpoint),
+			Location: settings.GetString(commands.KeyLocation),
+			Format:   settings.GetString(commands.FlagFormat),
+		}
+	}
+
+	return items, nil
+}
+
+func completeContext(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	items, err := contexts()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+
+	names := make([]string, len(items))
+	for i, item := range items {
+		names[i] = item.Name
+	}
+
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
+
+type contextListCommand struct {
+}
+
+func (c *contextListCommand) Run(cmd *cobra.Command, args []string) error {
+	items, err := contexts()
+	if err != nil {
+		return err
+	}
+
+	return commands.PrintStdout(items)
+}
+
+func (c *contextListCommand) Build(app commands.Application) *cobra.Command {
+	return &cobra.Command{
+		Use:   "list-contexts",
+		Short: "List profiles",
+		Long:  "Prints a table of all profiles stored in the config file.",
+		Args:  cobra.NoArgs,
+		RunE:  c.Run,
+	}
+}
+
+type contextUseCommand struct {
+}
+
+func (c *contextUseCommand) Run(cmd *cobra.Command, args []string) error {
+	profile := args[0]
+
+	return commands.EditConfig(func(file *viper.Viper) error {
+		if !file.IsSet(commands.ProfileKey(profile)) {
+			return fmt.Errorf("profile %q does not exist", profile)
+		}
+
+		file.Set(commands.KeyCurrentContext, profile)
+		return nil
+	})
+}
+
+func (c *contextUseCommand) CompleteArg(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) == 0 {
+		return completeContext(cmd, args, toComplete)
+	}
+
+	return nil, cobra.ShellCompDirectiveNoFileComp
+}
+
+func (c *contextUseCommand) Build(app commands.Application) *cobra.Command {
+	return &cobra.Command{
+		Use:   "use-context PROFILE",
+		Short: "Switch profile",
+		Long:  "Sets the profile which is used by default for all following commands.",
+		Example: commands.FormatExamples(fmt.Sprintf(`
+      # Use the production profile by default
+      %[1]s config use-context production
+      
+      # Run a single command with a different profile
+      %[1]s --profile staging compute server list
+		`, app.Name)),
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: c.CompleteArg,
+		RunE:              c.Run,
+	}
+}
+
+type contextCurrentCommand struct {
+}
+
+func (c *contextCurrentCommand) Run(cmd *cobra.Command, args []string) error {
+	if len(commands.Config.Profile) == 0 {
+		return fmt.Errorf("no profile is active")
+	}
+
+	commands.Stdout.Println(commands.Config.Profile)
+	return nil
+}
+
+func (c *contextCurrentCommand) Build(app commands.Application) *cobra.Command {
+	return &cobra.Command{
+		Use:   "current-context",
+		Short: "Show active profile",
+		Long:  "Prints the name of the currently active profile.",
+		Args:  cobra.NoArgs,
+		RunE:  c.Run,
+	}
+}
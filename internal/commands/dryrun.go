@@ -0,0 +1,109 @@
+package commands
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync"
+)
+
+// ErrDryRun is returned by the api client in dry run mode as soon as a
+// command attempts to modify a resource. The request is recorded in the plan
+// instead of being sent. As the command does not receive a response, further
+// steps depending on it are not planned, e.g. attaching a created volume. Only
+// ForEach continues with the remaining arguments.
+var ErrDryRun = errors.New("request not sent in dry run mode")
+
+// PlannedRequest is a modifying request which would have been sent if dry run
+// mode was disabled.
+type PlannedRequest struct {
+	Method string      `json:"method"`
+	Path   string      `json:"path"`
+	Body   interface{} `json:"body,omitempty"`
+}
+
+var (
+	plan      []PlannedRequest
+	planMutex sync.Mutex
+)
+
+// recordPlannedRequest adds the request to the plan with all secrets
+// redacted.
+func recordPlannedRequest(req *http.Request) error {
+	body, err := readBody(&req.Body)
+	if err != nil {
+		return err
+	}
+
+	planned := PlannedRequest{
+		Method: req.Method,
+		Path:   req.URL.RequestURI(),
+	}
+
+	if len(bytes.TrimSpace(body)) != 0 {
+		redacted := redactBody(body)
+
+		decoder := json.NewDecoder(bytes.NewReader(redacted))
+		decoder.UseNumber()
+
+		if err := decoder.Decode(&planned.Body); err != nil {
+			planned.Body = string(redacted)
+		}
+	}
+
+	planMutex.Lock()
+	defer planMutex.Unlock()
+
+	plan = append(plan, planned)
+	return nil
+}
+
+func hasPlan() bool {
+	planMutex.Lock()
+	defer planMutex.Unlock()
+
+	return len(plan) != 0
+}
+
+// printPlan prints all requests recorded in dry run mode. Structured output
+// formats print the plan as a list, all other formats print a human readable
+// description.
+func printPlan() error {
+	planMutex.Lock()
+	defer planMutex.Unlock()
+
+	if StructuredOutput() {
+		items := plan
+		if items == nil {
+			items = []PlannedRequest{}
+		}
+
+		return PrintStdout(items)
+	}
+
+	if len(plan) == 0 {
+		Stdout.Println("Dry run: no changes would have been made.")
+		return nil
+	}
+
+	Stdout.Println("Dry run: the following requests would have been sent:")
+
+	for _, planned := range plan {
+		Stdout.Println()
+		Stdout.Bold().Printf("  %s %s\n", planned.Method, planned.Path).Reset()
+
+		if planned.Body == nil {
+			continue
+		}
+
+		data, err := json.MarshalIndent(planned.Body, "    ", "  ")
+		if err != nil {
+			return err
+		}
+
+		Stdout.Printf("    %s\n", data)
+	}
+
+	return nil
+}
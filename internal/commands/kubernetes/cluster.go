@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/base64"
 	"fmt"
+	"time"
 
 	"github.com/spf13/cobra"
 
@@ -12,6 +13,7 @@ import (
 	"github.com/flowswiss/cli/v2/pkg/api/compute"
 	"github.com/flowswiss/cli/v2/pkg/api/kubernetes"
 	"github.com/flowswiss/cli/v2/pkg/filter"
+	"github.com/flowswiss/cli/v2/pkg/resolve"
 )
 
 func ClusterCommand(app commands.Application) *cobra.Command {
@@ -51,7 +53,10 @@ func (c *clusterListCommand) Run(cmd *cobra.Command, args []string) error {
 	}
 
 	if len(c.filter) != 0 {
-		items = filter.Find(items, c.filter)
+		items, err = filter.Find(items, c.filter)
+		if err != nil {
+			return err
+		}
 	}
 
 	return commands.PrintStdout(items)
@@ -71,7 +76,7 @@ func (c *clusterListCommand) Build(app commands.Application) *cobra.Command {
 		RunE:              c.Run,
 	}
 
-	cmd.Flags().StringVar(&c.filter, "filter", "", "custom term to filter the results")
+	commands.AddFilterFlag(cmd, &c.filter, "location=ALP1")
 
 	return cmd
 }
@@ -83,6 +88,7 @@ type clusterCreateCommand struct {
 	workerProduct    string
 	workerCount      int
 	attachExternalIP bool
+	noWait           bool
 }
 
 func (c *clusterCreateCommand) Run(cmd *cobra.Command, args []string) error {
@@ -138,6 +144,10 @@ func (c *clusterCreateCommand) Run(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("create cluster: %w", err)
 	}
 
+	if c.noWait {
+		return commands.PrintOrdering(ordering)
+	}
+
 	order, err := commands.WaitForOrder(cmd.Context(), "Creating cluster", ordering)
 	if err != nil {
 		return fmt.Errorf("wait for order: %w", err)
@@ -174,6 +184,7 @@ func (c *clusterCreateCommand) Build(app commands.Application) *cobra.Command {
 	cmd.Flags().StringVar(&c.workerProduct, "worker-product", "", "product for the worker nodes (required)")
 	cmd.Flags().IntVar(&c.workerCount, "worker-count", 3, "number of worker nodes")
 	cmd.Flags().BoolVar(&c.attachExternalIP, "attach-external-ip", true, "whether to attach an elastic ip to the cluster")
+	cmd.Flags().BoolVar(&c.noWait, "no-wait", false, "print the order and return without waiting until the cluster is ready")
 
 	_ = cmd.MarkFlagRequired("name")
 	_ = cmd.MarkFlagRequired("location")
@@ -336,6 +347,13 @@ func (c *clusterUpgradeCommand) Build(app commands.Application) *cobra.Command {
 	return cmd
 }
 
+type clusterKubeConfig struct {
+	Cluster    string    `json:"cluster"`
+	UpdatedAt  time.Time `json:"updated_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+	KubeConfig string    `json:"kube_config"`
+}
+
 type clusterKubeConfigCommand struct {
 }
 
@@ -355,6 +373,15 @@ func (c *clusterKubeConfigCommand) Run(cmd *cobra.Command, args []string) error
 		return fmt.Errorf("decode base64 kube-config: %w", err)
 	}
 
+	if commands.StructuredOutput() {
+		return commands.PrintStdout(clusterKubeConfig{
+			Cluster:    cluster.Name,
+			UpdatedAt:  cluster.KubeConfig.UpdatedAt.AsTime(),
+			ExpiresAt:  cluster.KubeConfig.ExpiresAt.AsTime(),
+			KubeConfig: string(decoded),
+		})
+	}
+
 	commands.Stdout.Println(string(decoded))
 	return nil
 }
@@ -371,7 +398,7 @@ func (c *clusterKubeConfigCommand) Build(app commands.Application) *cobra.Comman
 	cmd := &cobra.Command{
 		Use:               "kube-config CLUSTER",
 		Short:             "Display kube config",
-		Long:              "Displays the kubernetes cluster kube config to access the cluster",
+		Long:              "Displays the kubernetes cluster kube config to access the cluster. Using the json or yaml output format, the kube config is printed together with its metadata.",
 		Args:              cobra.ExactArgs(1),
 		ValidArgsFunction: c.CompleteArg,
 		RunE:              c.Run,
@@ -381,12 +408,15 @@ func (c *clusterKubeConfigCommand) Build(app commands.Application) *cobra.Comman
 }
 
 func completeCluster(ctx context.Context, term string) ([]string, cobra.ShellCompDirective) {
-	clusters, err := kubernetes.NewClusterService(commands.Config.Client).List(ctx)
+	clusters, err := clusterResolver.List(ctx)
 	if err != nil {
 		return nil, cobra.ShellCompDirectiveError
 	}
 
-	filtered := filter.Find(clusters, term)
+	filtered, err := filter.Find(clusters, term)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
 
 	names := make([]string, len(filtered))
 	for i, cluster := range filtered {
@@ -396,13 +426,22 @@ func completeCluster(ctx context.Context, term string) ([]string, cobra.ShellCom
 	return names, cobra.ShellCompDirectiveNoFileComp
 }
 
-func findCluster(ctx context.Context, term string) (kubernetes.Cluster, error) {
-	clusters, err := kubernetes.NewClusterService(commands.Config.Client).List(ctx)
-	if err != nil {
-		return kubernetes.Cluster{}, fmt.Errorf("fetch clusters: %w", err)
-	}
+var clusterResolver = resolve.New(
+	func(ctx context.Context) ([]kubernetes.Cluster, error) {
+		items, err := kubernetes.NewClusterService(commands.Config.Client).List(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("fetch clusters: %w", err)
+		}
+
+		return items, nil
+	},
+	func(ctx context.Context, id int) (kubernetes.Cluster, error) {
+		return kubernetes.NewClusterService(commands.Config.Client).Get(ctx, id)
+	},
+)
 
-	cluster, err := filter.FindOne(clusters, term)
+func findCluster(ctx context.Context, term string) (kubernetes.Cluster, error) {
+	cluster, err := clusterResolver.Find(ctx, term)
 	if err != nil {
 		return kubernetes.Cluster{}, fmt.Errorf("find cluster: %w", err)
 	}
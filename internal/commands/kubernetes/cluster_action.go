@@ -41,7 +41,10 @@ func (c *clusterActionListCommand) Run(cmd *cobra.Command, args []string) error
 	}
 
 	if len(c.filter) != 0 {
-		actions = filter.Find(actions, c.filter)
+		actions, err = filter.Find(actions, c.filter)
+		if err != nil {
+			return err
+		}
 	}
 
 	return commands.PrintStdout(actions)
@@ -66,7 +69,7 @@ func (c *clusterActionListCommand) Build(app commands.Application) *cobra.Comman
 		RunE:              c.Run,
 	}
 
-	cmd.Flags().StringVar(&c.filter, "filter", "", "custom term to filter the results")
+	commands.AddFilterFlag(cmd, &c.filter, "name~start")
 
 	return cmd
 }
@@ -117,7 +120,10 @@ func (c *clusterActionRunCommand) CompleteArg(cmd *cobra.Command, args []string,
 			actions[i] = kubernetes.ClusterAction(action)
 		}
 
-		filtered := filter.Find(actions, toComplete)
+		filtered, err := filter.Find(actions, toComplete)
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveError
+		}
 
 		names := make([]string, len(filtered))
 		for i, action := range filtered {
@@ -38,7 +38,10 @@ func (l *loadBalancerListCommand) Run(cmd *cobra.Command, args []string) error {
 	}
 
 	if len(l.filter) != 0 {
-		items = filter.Find(items, l.filter)
+		items, err = filter.Find(items, l.filter)
+		if err != nil {
+			return err
+		}
 	}
 
 	return commands.PrintStdout(items)
@@ -63,7 +66,7 @@ func (l *loadBalancerListCommand) Build(app commands.Application) *cobra.Command
 		RunE:              l.Run,
 	}
 
-	cmd.Flags().StringVar(&l.filter, "filter", "", "custom term to filter the results")
+	commands.AddFilterFlag(cmd, &l.filter, "location=ALP1")
 
 	return cmd
 }
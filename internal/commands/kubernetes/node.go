@@ -46,7 +46,10 @@ func (n *nodeListCommand) Run(cmd *cobra.Command, args []string) error {
 	}
 
 	if len(n.filter) != 0 {
-		items = filter.Find(items, n.filter)
+		items, err = filter.Find(items, n.filter)
+		if err != nil {
+			return err
+		}
 	}
 
 	return commands.PrintStdout(items)
@@ -71,7 +74,7 @@ func (n *nodeListCommand) Build(app commands.Application) *cobra.Command {
 		RunE:              n.Run,
 	}
 
-	cmd.Flags().StringVar(&n.filter, "filter", "", "custom term to filter the results")
+	commands.AddFilterFlag(cmd, &n.filter, "roles~worker")
 
 	return cmd
 }
@@ -142,7 +145,10 @@ func completeNode(ctx context.Context, cluster kubernetes.Cluster, term string)
 		return nil, cobra.ShellCompDirectiveError
 	}
 
-	filtered := filter.Find(nodes, term)
+	filtered, err := filter.Find(nodes, term)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
 
 	names := make([]string, len(filtered))
 	for i, node := range filtered {
@@ -46,7 +46,10 @@ func (n *nodeActionListCommand) Run(cmd *cobra.Command, args []string) error {
 	}
 
 	if len(n.filter) != 0 {
-		actions = filter.Find(actions, n.filter)
+		actions, err = filter.Find(actions, n.filter)
+		if err != nil {
+			return err
+		}
 	}
 
 	return commands.PrintStdout(actions)
@@ -80,7 +83,7 @@ func (n *nodeActionListCommand) Build(app commands.Application) *cobra.Command {
 		RunE:              n.Run,
 	}
 
-	cmd.Flags().StringVar(&n.filter, "filter", "", "custom term to filter the results")
+	commands.AddFilterFlag(cmd, &n.filter, "name~start")
 
 	return cmd
 }
@@ -150,7 +153,10 @@ func (n *nodeActionRunCommand) CompleteArg(cmd *cobra.Command, args []string, to
 			actions[i] = kubernetes.NodeAction(action)
 		}
 
-		filtered := filter.Find(actions, toComplete)
+		filtered, err := filter.Find(actions, toComplete)
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveError
+		}
 
 		names := make([]string, len(filtered))
 		for i, action := range filtered {
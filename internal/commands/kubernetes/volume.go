@@ -42,7 +42,10 @@ func (v *volumeListCommand) Run(cmd *cobra.Command, args []string) error {
 	}
 
 	if len(v.filter) != 0 {
-		items = filter.Find(items, v.filter)
+		items, err = filter.Find(items, v.filter)
+		if err != nil {
+			return err
+		}
 	}
 
 	return commands.PrintStdout(items)
@@ -67,7 +70,7 @@ func (v *volumeListCommand) Build(app commands.Application) *cobra.Command {
 		RunE:              v.Run,
 	}
 
-	cmd.Flags().StringVar(&v.filter, "filter", "", "custom term to filter the results")
+	commands.AddFilterFlag(cmd, &v.filter, "name~^pvc-")
 
 	return cmd
 }
@@ -138,7 +141,10 @@ func completeVolume(ctx context.Context, cluster kubernetes.Cluster, term string
 		return nil, cobra.ShellCompDirectiveError
 	}
 
-	filtered := filter.Find(volumes, term)
+	filtered, err := filter.Find(volumes, term)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
 
 	names := make([]string, len(filtered))
 	for i, volume := range filtered {
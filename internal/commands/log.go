@@ -0,0 +1,145 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/viper"
+
+	"github.com/flowswiss/cli/v2/pkg/console"
+)
+
+const (
+	LogFormatText = "text"
+	LogFormatJSON = "json"
+)
+
+const (
+	// LogLevelRequests logs every request sent to the api.
+	LogLevelRequests = 1
+	// LogLevelDebug additionally logs retries and cached responses.
+	LogLevelDebug = 2
+)
+
+// Log is the logger for diagnostic messages configured using the verbose,
+// log-file and log-format flags.
+var Log = &Logger{}
+
+// LogEntry is a single structured log message.
+type LogEntry struct {
+	Time      time.Time `json:"time"`
+	Message   string    `json:"msg"`
+	Method    string    `json:"method,omitempty"`
+	URL       string    `json:"url,omitempty"`
+	Status    int       `json:"status,omitempty"`
+	LatencyMS int64     `json:"latency_ms,omitempty"`
+	RequestID string    `json:"request_id,omitempty"`
+	Attempt   int       `json:"attempt,omitempty"`
+	Error     string    `json:"error,omitempty"`
+}
+
+type Logger struct {
+	Level  int
+	Format string
+	Out    io.Writer
+
+	mutex sync.Mutex
+}
+
+// Enabled returns whether messages of the given level are logged.
+func (l *Logger) Enabled(level int) bool {
+	return l.Out != nil && l.Level >= level
+}
+
+// Print writes the entry if messages of the given level are enabled.
+func (l *Logger) Print(level int, entry LogEntry) {
+	if !l.Enabled(level) {
+		return
+	}
+
+	if entry.Time.IsZero() {
+		entry.Time = time.Now()
+	}
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if l.Format == LogFormatJSON {
+		_ = json.NewEncoder(l.Out).Encode(entry)
+		return
+	}
+
+	line := formatLogEntry(entry)
+	if writer, ok := l.Out.(console.Writer); ok {
+		writer.Color(console.Bright + console.Black).Println(line).Reset()
+		return
+	}
+
+	_, _ = fmt.Fprintf(l.Out, "%s %s\n", entry.Time.Format(time.RFC3339), line)
+}
+
+func formatLogEntry(entry LogEntry) string {
+	builder := &strings.Builder{}
+	builder.WriteString(entry.Message)
+
+	if len(entry.Method) != 0 {
+		fmt.Fprintf(builder, " `%s %s`", entry.Method, entry.URL)
+	}
+
+	if entry.Status != 0 {
+		fmt.Fprintf(builder, " status=%d", entry.Status)
+	}
+
+	if entry.LatencyMS != 0 {
+		fmt.Fprintf(builder, " latency=%dms", entry.LatencyMS)
+	}
+
+	if len(entry.RequestID) != 0 {
+		fmt.Fprintf(builder, " request-id=%s", entry.RequestID)
+	}
+
+	if entry.Attempt != 0 {
+		fmt.Fprintf(builder, " attempt=%d", entry.Attempt)
+	}
+
+	if len(entry.Error) != 0 {
+		fmt.Fprintf(builder, " error=%q", entry.Error)
+	}
+
+	return builder.String()
+}
+
+// setupLogging configures the logger using the verbose, log-file and
+// log-format settings. Writing to a log file implies logging all requests.
+func setupLogging() error {
+	format := viper.GetString(FlagLogFormat)
+	if format != LogFormatText && format != LogFormatJSON {
+		return fmt.Errorf("unknown log format %q, allowed values are: %s, %s", format, LogFormatText, LogFormatJSON)
+	}
+
+	Log.Level = viper.GetInt(FlagVerbose)
+	Log.Format = format
+	Log.Out = Stderr
+
+	path := viper.GetString(FlagLogFile)
+	if len(path) == 0 {
+		return nil
+	}
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return fmt.Errorf("open log file: %w", err)
+	}
+
+	Log.Out = file
+	if Log.Level < LogLevelRequests {
+		Log.Level = LogLevelRequests
+	}
+
+	return nil
+}
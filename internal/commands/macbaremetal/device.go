@@ -11,6 +11,7 @@ import (
 	"github.com/flowswiss/cli/v2/pkg/api/common"
 	"github.com/flowswiss/cli/v2/pkg/api/macbaremetal"
 	"github.com/flowswiss/cli/v2/pkg/filter"
+	"github.com/flowswiss/cli/v2/pkg/resolve"
 )
 
 func DeviceCommand(app commands.Application) *cobra.Command {
@@ -59,7 +60,10 @@ func (d *deviceListCommand) Run(cmd *cobra.Command, args []string) error {
 	}
 
 	if len(d.filter) != 0 {
-		items = filter.Find(items, d.filter)
+		items, err = filter.Find(items, d.filter)
+		if err != nil {
+			return err
+		}
 	}
 
 	return commands.PrintStdout(items)
@@ -89,7 +93,7 @@ func (d *deviceListCommand) Build(app commands.Application) *cobra.Command {
 		RunE:              d.Run,
 	}
 
-	cmd.Flags().StringVar(&d.filter, "filter", "", "custom term to filter the results")
+	commands.AddFilterFlag(cmd, &d.filter, "location=ZRH1,name~^build-")
 
 	return cmd
 }
@@ -156,6 +160,7 @@ type deviceCreateCommand struct {
 	network         string
 	attachElasticIP bool
 	password        string
+	noWait          bool
 }
 
 func (d *deviceCreateCommand) Run(cmd *cobra.Command, args []string) error {
@@ -195,6 +200,10 @@ func (d *deviceCreateCommand) Run(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("create device: %w", err)
 	}
 
+	if d.noWait {
+		return commands.PrintOrdering(ordering)
+	}
+
 	order, err := commands.WaitForOrder(cmd.Context(), "Creating device", ordering)
 	if err != nil {
 		return fmt.Errorf("wait for order: %w", err)
@@ -228,6 +237,7 @@ func (d *deviceCreateCommand) Build(app commands.Application) *cobra.Command {
 	cmd.Flags().StringVar(&d.network, "network", "", "network to be attached to the device")
 	cmd.Flags().BoolVar(&d.attachElasticIP, "attach-elastic-ip", false, "whether to attach an elastic ip to the device")
 	cmd.Flags().StringVar(&d.password, "password", "", "password to be applied to the device") // TODO this is insecure and should be removed
+	cmd.Flags().BoolVar(&d.noWait, "no-wait", false, "print the order and return without waiting until the device is ready")
 
 	_ = cmd.MarkFlagRequired("name")
 	_ = cmd.MarkFlagRequired("product")
@@ -354,12 +364,15 @@ func (d *deviceDeleteCommand) Build(app commands.Application) *cobra.Command {
 }
 
 func completeDevice(ctx context.Context, term string) ([]string, cobra.ShellCompDirective) {
-	devices, err := macbaremetal.NewDeviceService(commands.Config.Client).List(ctx)
+	devices, err := deviceResolver.List(ctx)
 	if err != nil {
 		return nil, cobra.ShellCompDirectiveError
 	}
 
-	filtered := filter.Find(devices, term)
+	filtered, err := filter.Find(devices, term)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
 
 	names := make([]string, len(filtered))
 	for i, d := range filtered {
@@ -369,16 +382,25 @@ func completeDevice(ctx context.Context, term string) ([]string, cobra.ShellComp
 	return names, cobra.ShellCompDirectiveNoFileComp
 }
 
-func findDevice(ctx context.Context, term string) (macbaremetal.Device, error) {
-	elasticIPs, err := macbaremetal.NewDeviceService(commands.Config.Client).List(ctx)
-	if err != nil {
-		return macbaremetal.Device{}, fmt.Errorf("fetch devices: %w", err)
-	}
+var deviceResolver = resolve.New(
+	func(ctx context.Context) ([]macbaremetal.Device, error) {
+		items, err := macbaremetal.NewDeviceService(commands.Config.Client).List(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("fetch devices: %w", err)
+		}
 
-	elasticIP, err := filter.FindOne(elasticIPs, term)
+		return items, nil
+	},
+	func(ctx context.Context, id int) (macbaremetal.Device, error) {
+		return macbaremetal.NewDeviceService(commands.Config.Client).Get(ctx, id)
+	},
+)
+
+func findDevice(ctx context.Context, term string) (macbaremetal.Device, error) {
+	device, err := deviceResolver.Find(ctx, term)
 	if err != nil {
 		return macbaremetal.Device{}, fmt.Errorf("find device: %w", err)
 	}
 
-	return elasticIP, nil
+	return device, nil
 }
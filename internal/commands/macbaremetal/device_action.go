@@ -280,7 +280,10 @@ func completeWorkflow(ctx context.Context, device macbaremetal.Device, term stri
 		return nil, cobra.ShellCompDirectiveError
 	}
 
-	filtered := filter.Find(workflows, term)
+	filtered, err := filter.Find(workflows, term)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
 
 	names := make([]string, len(filtered))
 	for i, w := range filtered {
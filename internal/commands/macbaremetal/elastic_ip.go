@@ -51,7 +51,10 @@ func (e *elasticIPListCommand) Run(cmd *cobra.Command, args []string) error {
 	}
 
 	if len(e.filter) != 0 {
-		items = filter.Find(items, e.filter)
+		items, err = filter.Find(items, e.filter)
+		if err != nil {
+			return err
+		}
 	}
 
 	return commands.PrintStdout(items)
@@ -71,7 +74,7 @@ func (e *elasticIPListCommand) Build(app commands.Application) *cobra.Command {
 		RunE:              e.Run,
 	}
 
-	cmd.Flags().StringVar(&e.filter, "filter", "", "custom term to filter the results")
+	commands.AddFilterFlag(cmd, &e.filter, "location=ZRH1")
 
 	return cmd
 }
@@ -336,7 +339,10 @@ func completeElasticIP(ctx context.Context, term string, itemFilter func(ip macb
 		return nil, cobra.ShellCompDirectiveError
 	}
 
-	filtered := filter.FindWithCustomFilter(elasticIPs, term, itemFilter)
+	filtered, err := filter.FindWithCustomFilter(elasticIPs, term, itemFilter)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
 
 	names := make([]string, len(filtered))
 	for i, elasticIP := range filtered {
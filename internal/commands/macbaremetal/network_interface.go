@@ -147,7 +147,10 @@ func completeNetworkInterface(ctx context.Context, device macbaremetal.Device, t
 		return nil, cobra.ShellCompDirectiveError
 	}
 
-	filtered := filter.Find(interfaces, term)
+	filtered, err := filter.Find(interfaces, term)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
 
 	names := make([]string, len(filtered))
 	for i, iface := range filtered {
@@ -41,7 +41,10 @@ func (s *securityGroupListCommand) Run(cmd *cobra.Command, args []string) error
 	}
 
 	if len(s.filter) != 0 {
-		items = filter.Find(items, s.filter)
+		items, err = filter.Find(items, s.filter)
+		if err != nil {
+			return err
+		}
 	}
 
 	return commands.PrintStdout(items)
@@ -61,7 +64,7 @@ func (s *securityGroupListCommand) Build(app commands.Application) *cobra.Comman
 		RunE:              s.Run,
 	}
 
-	cmd.Flags().StringVar(&s.filter, "filter", "", "custom term to filter the results")
+	commands.AddFilterFlag(cmd, &s.filter, "network=my-network")
 
 	return cmd
 }
@@ -236,7 +239,10 @@ func completeSecurityGroup(ctx context.Context, term string) ([]string, cobra.Sh
 		return nil, cobra.ShellCompDirectiveError
 	}
 
-	filtered := filter.Find(securityGroups, term)
+	filtered, err := filter.Find(securityGroups, term)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
 
 	names := make([]string, len(filtered))
 	for i, securityGroup := range filtered {
@@ -55,7 +55,10 @@ func (s *securityGroupRuleListCommand) Run(cmd *cobra.Command, args []string) er
 	}
 
 	if len(s.filter) != 0 {
-		items = filter.Find(items, s.filter)
+		items, err = filter.Find(items, s.filter)
+		if err != nil {
+			return err
+		}
 	}
 
 	return commands.PrintStdout(items)
@@ -80,7 +83,7 @@ func (s *securityGroupRuleListCommand) Build(app commands.Application) *cobra.Co
 		RunE:              s.Run,
 	}
 
-	cmd.Flags().StringVar(&s.filter, "filter", "", "custom term to filter the results")
+	commands.AddFilterFlag(cmd, &s.filter, "direction=ingress,protocol=tcp")
 
 	return cmd
 }
@@ -334,7 +337,10 @@ func completeSecurityGroupRule(ctx context.Context, securityGroup macbaremetal.S
 		return nil, cobra.ShellCompDirectiveError
 	}
 
-	filtered := filter.Find(rules, term)
+	filtered, err := filter.Find(rules, term)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
 
 	names := make([]string, len(filtered))
 	for i, rule := range filtered {
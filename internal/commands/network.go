@@ -0,0 +1,153 @@
+package commands
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// networkTransport is the transport used to send requests to the api. It is
+// nil if no network settings are configured, in which case the default
+// transport is used.
+var networkTransport *http.Transport
+
+// setupNetwork builds the transport according to the proxy and tls settings.
+// Insecure settings are reported on stderr if warn is set.
+func setupNetwork(warn bool) error {
+	proxy := viper.GetString(FlagHTTPSProxy)
+	noProxy := viper.GetString(FlagNoProxy)
+	caFile := viper.GetString(FlagCAFile)
+	insecure := viper.GetBool(FlagInsecureSkipVerify)
+	clientCert, clientKey := viper.GetString(FlagClientCert), viper.GetString(FlagClientKey)
+
+	networkTransport = nil
+	if len(proxy) == 0 && len(noProxy) == 0 && len(caFile) == 0 && !insecure && len(clientCert) == 0 && len(clientKey) == 0 {
+		return nil
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{}
+
+	// without an explicit proxy, the proxy of the environment is used
+	transport.Proxy = proxyFunc(http.ProxyFromEnvironment, noProxy)
+	if len(proxy) != 0 {
+		proxyURL, err := url.Parse(proxy)
+		if err != nil || len(proxyURL.Host) == 0 {
+			return fmt.Errorf("invalid %s %q: must be an url like http://proxy.example.com:3128", FlagHTTPSProxy, RedactProxy(proxy))
+		}
+
+		transport.Proxy = proxyFunc(http.ProxyURL(proxyURL), noProxy)
+	}
+
+	if len(caFile) != 0 {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+
+		data, err := os.ReadFile(caFile)
+		if err != nil {
+			return fmt.Errorf("read ca file: %w", err)
+		}
+
+		if !pool.AppendCertsFromPEM(data) {
+			return fmt.Errorf("ca file %q does not contain any pem encoded certificate", caFile)
+		}
+
+		transport.TLSClientConfig.RootCAs = pool
+	}
+
+	if len(clientCert) != 0 || len(clientKey) != 0 {
+		if len(clientCert) == 0 || len(clientKey) == 0 {
+			return fmt.Errorf("the settings %s and %s must be used together", FlagClientCert, FlagClientKey)
+		}
+
+		cert, err := tls.LoadX509KeyPair(clientCert, clientKey)
+		if err != nil {
+			return fmt.Errorf("load client certificate: %w", err)
+		}
+
+		transport.TLSClientConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	if insecure {
+		if warn {
+			Stderr.Errorf("WARNING: TLS certificate verification is disabled. Connections to the api are not secure and can be intercepted.\n")
+		}
+
+		transport.TLSClientConfig.InsecureSkipVerify = true
+	}
+
+	Log.Print(LogLevelRequests, LogEntry{
+		Message: fmt.Sprintf("network settings: %s=%q %s=%q %s=%q %s=%t %s=%q",
+			FlagHTTPSProxy, RedactProxy(proxy), FlagNoProxy, noProxy, FlagCAFile, caFile,
+			FlagInsecureSkipVerify, insecure, FlagClientCert, clientCert),
+	})
+
+	networkTransport = transport
+	return nil
+}
+
+// proxyFunc returns a proxy function sending all requests through the given
+// proxy, except the ones to hosts matching the comma separated no proxy list.
+func proxyFunc(proxy func(req *http.Request) (*url.URL, error), noProxy string) func(req *http.Request) (*url.URL, error) {
+	return func(req *http.Request) (*url.URL, error) {
+		if bypassProxy(req.URL.Hostname(), noProxy) {
+			return nil, nil
+		}
+
+		return proxy(req)
+	}
+}
+
+// bypassProxy reports whether the host matches one of the entries of the
+// comma separated no proxy list. An entry matches the host itself and all of
+// its subdomains, "*" matches every host.
+func bypassProxy(host, noProxy string) bool {
+	host = strings.ToLower(host)
+
+	for _, entry := range strings.Split(noProxy, ",") {
+		entry = strings.ToLower(strings.TrimSpace(entry))
+		if len(entry) == 0 {
+			continue
+		}
+
+		if entry == "*" {
+			return true
+		}
+
+		if h, _, err := net.SplitHostPort(entry); err == nil {
+			entry = h
+		}
+
+		entry = strings.TrimPrefix(entry, "*")
+		entry = strings.TrimPrefix(entry, ".")
+
+		if host == entry || strings.HasSuffix(host, "."+entry) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// RedactProxy removes the password from the proxy url.
+func RedactProxy(proxy string) string {
+	proxyURL, err := url.Parse(proxy)
+	if err != nil || proxyURL.User == nil {
+		return proxy
+	}
+
+	if _, ok := proxyURL.User.Password(); ok {
+		proxyURL.User = url.UserPassword(proxyURL.User.Username(), redactedValue)
+	}
+
+	return proxyURL.String()
+}
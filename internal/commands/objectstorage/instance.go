@@ -39,7 +39,10 @@ func (i *instanceListCommand) Run(cmd *cobra.Command, args []string) error {
 	}
 
 	if len(i.filter) != 0 {
-		items = filter.Find(items, i.filter)
+		items, err = filter.Find(items, i.filter)
+		if err != nil {
+			return err
+		}
 	}
 
 	return commands.PrintStdout(items)
@@ -54,7 +57,7 @@ func (i *instanceListCommand) Build(app commands.Application) *cobra.Command {
 		RunE:    i.Run,
 	}
 
-	cmd.Flags().StringVar(&i.filter, "filter", "", "custom term to filter the results")
+	commands.AddFilterFlag(cmd, &i.filter, "location=ZRH1")
 
 	return cmd
 }
@@ -153,7 +156,10 @@ func (c *credentialsCommand) Run(cmd *cobra.Command, args []string) error {
 	}
 
 	if len(c.filter) != 0 {
-		credentials = filter.Find(credentials, c.filter)
+		credentials, err = filter.Find(credentials, c.filter)
+		if err != nil {
+			return err
+		}
 	}
 
 	return commands.PrintStdout(credentials)
@@ -167,7 +173,7 @@ func (c *credentialsCommand) Build(app commands.Application) *cobra.Command {
 		RunE:  c.Run,
 	}
 
-	cmd.Flags().StringVar(&c.filter, "filter", "", "custom term to filter the results")
+	commands.AddFilterFlag(cmd, &c.filter, "location=ZRH1")
 
 	return cmd
 }
@@ -0,0 +1,282 @@
+package commands
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strings"
+	"text/template"
+
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+
+	"github.com/flowswiss/cli/v2/pkg/console"
+	"github.com/flowswiss/cli/v2/pkg/jsonpath"
+)
+
+const (
+	FormatJSON           = "json"
+	FormatNDJSON         = "ndjson"
+	FormatYAML           = "yaml"
+	FormatTable          = "table"
+	FormatWide           = "wide"
+	FormatCSV            = "csv"
+	FormatTSV            = "tsv"
+	FormatGoTemplate     = "go-template"
+	FormatGoTemplateFile = "go-template-file"
+	FormatJSONPath       = "jsonpath"
+)
+
+func Print(out console.Writer, val interface{}) error {
+	format, argument := outputFormat()
+
+	if column := viper.GetString(FlagSortBy); len(column) != 0 {
+		if err := console.Sort(val, column, viper.GetBool(FlagReverse)); err != nil {
+			return err
+		}
+	}
+
+	switch format {
+	case FormatJSON:
+		return json.NewEncoder(out).Encode(val)
+	case FormatNDJSON:
+		return encodeNDJSON(out, val)
+	case FormatYAML:
+		return encodeYAML(out, val)
+	case FormatGoTemplate, FormatGoTemplateFile:
+		return executeGoTemplate(out, format, argument, val)
+	case FormatJSONPath:
+		return executeJSONPath(out, argument, val)
+	case FormatTable, FormatWide, FormatCSV, FormatTSV:
+	default:
+		return fmt.Errorf("unknown output format %q", format)
+	}
+
+	table := console.Table{
+		HideHeaders: viper.GetBool(FlagNoHeaders),
+	}
+
+	if format == FormatTable && !viper.GetBool(FlagNoTruncate) {
+		table.MaxWidth = console.TerminalWidth(out)
+	}
+
+	err := table.Insert(val)
+	if err != nil {
+		return err
+	}
+
+	if columns := displayedColumns(&table, format); columns != nil {
+		if err := table.SelectColumns(columns); err != nil {
+			return err
+		}
+	}
+
+	switch format {
+	case FormatCSV:
+		err = table.WriteCSV(out)
+	case FormatTSV:
+		err = table.WriteTSV(out)
+	default:
+		table.Format(out, "   ")
+	}
+
+	if err != nil {
+		return err
+	}
+
+	Stderr.Printf("Found a total of %d items\n", len(table.Rows))
+	return nil
+}
+
+func PrintStdout(val interface{}) error {
+	return Print(Stdout, val)
+}
+
+// StructuredOutput reports whether the selected output format is a machine
+// readable serialization or template instead of a table.
+func StructuredOutput() bool {
+	format, _ := outputFormat()
+	return format != FormatTable && format != FormatWide && format != FormatCSV && format != FormatTSV
+}
+
+// displayedColumns returns the columns to display in table output. Columns
+// selected by flag take precedence over the columns configured for the
+// displayed type. Without any selection, the table format only shows the
+// compact columns while all other formats show all columns.
+func displayedColumns(table *console.Table, format string) []string {
+	if columns := settingList(FlagColumns); len(columns) != 0 {
+		return columns
+	}
+
+	if format != FormatTable {
+		return nil
+	}
+
+	if kind := table.Kind(); len(kind) != 0 {
+		if columns := settingList(KeyColumns + "." + kind); len(columns) != 0 {
+			return columns
+		}
+	}
+
+	return table.CompactColumns()
+}
+
+// settingList reads a list setting, which is either stored as a list or as a
+// comma separated string.
+func settingList(key string) []string {
+	switch value := viper.Get(key).(type) {
+	case []string:
+		return value
+	case []interface{}:
+		items := make([]string, len(value))
+		for idx, item := range value {
+			items[idx] = fmt.Sprint(item)
+		}
+		return items
+	case string:
+		if len(value) == 0 {
+			return nil
+		}
+		return strings.Split(value, ",")
+	}
+
+	return nil
+}
+
+func streamingOutput() bool {
+	format, _ := outputFormat()
+	return format == FormatNDJSON
+}
+
+// encodeNDJSON writes every element of a slice as a separate json document on
+// its own line. Other values are written as a single line.
+func encodeNDJSON(out io.Writer, val interface{}) error {
+	encoder := json.NewEncoder(out)
+
+	value := reflect.ValueOf(val)
+	if value.Kind() != reflect.Slice && value.Kind() != reflect.Array {
+		return encoder.Encode(val)
+	}
+
+	for i := 0; i < value.Len(); i++ {
+		if err := encoder.Encode(value.Index(i).Interface()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// outputFormat splits the format flag into the name of the format and its
+// argument, e.g. the template of `go-template={{.Name}}`.
+func outputFormat() (string, string) {
+	format := viper.GetString(FlagFormat)
+
+	if idx := strings.IndexByte(format, '='); idx >= 0 {
+		return format[:idx], format[idx+1:]
+	}
+
+	return format, ""
+}
+
+func executeGoTemplate(out io.Writer, format string, argument string, val interface{}) error {
+	if len(argument) == 0 {
+		return fmt.Errorf("output format %s requires an argument, e.g. -o %s=...", format, format)
+	}
+
+	text := argument
+	if format == FormatGoTemplateFile {
+		data, err := os.ReadFile(argument)
+		if err != nil {
+			return fmt.Errorf("read template file: %w", err)
+		}
+
+		text = string(data)
+	}
+
+	tmpl, err := template.New(format).Parse(text)
+	if err != nil {
+		return fmt.Errorf("parse go-template: %w", err)
+	}
+
+	if err := tmpl.Execute(out, val); err != nil {
+		return fmt.Errorf("execute go-template: %w", err)
+	}
+
+	return nil
+}
+
+// executeJSONPath evaluates the jsonpath template against the json
+// representation of the value. Lists are available in the items field, in
+// the same way as kubectl exposes them.
+func executeJSONPath(out io.Writer, argument string, val interface{}) error {
+	if len(argument) == 0 {
+		return fmt.Errorf("output format %s requires an argument, e.g. -o %s='{.items[*].name}'", FormatJSONPath, FormatJSONPath)
+	}
+
+	tmpl, err := jsonpath.Parse(argument)
+	if err != nil {
+		return fmt.Errorf("parse jsonpath: %w", err)
+	}
+
+	data, err := json.Marshal(val)
+	if err != nil {
+		return err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var document interface{}
+	if err := decoder.Decode(&document); err != nil {
+		return err
+	}
+
+	if items, ok := document.([]interface{}); ok {
+		document = map[string]interface{}{"items": items}
+	}
+
+	if err := tmpl.Execute(out, document); err != nil {
+		return fmt.Errorf("execute jsonpath: %w", err)
+	}
+
+	return nil
+}
+
+// encodeYAML serializes the value using its json representation. Going
+// through json keeps field names identical to the json output and preserves
+// the field order of structs.
+func encodeYAML(out io.Writer, val interface{}) error {
+	data, err := json.Marshal(val)
+	if err != nil {
+		return err
+	}
+
+	node := &yaml.Node{}
+	if err := yaml.Unmarshal(data, node); err != nil {
+		return err
+	}
+
+	resetYAMLStyle(node)
+
+	encoder := yaml.NewEncoder(out)
+	encoder.SetIndent(2)
+
+	if err := encoder.Encode(node); err != nil {
+		return err
+	}
+
+	return encoder.Close()
+}
+
+// resetYAMLStyle removes the flow and quoting style inherited from the json
+// source, so the document is rendered in block style.
+func resetYAMLStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		resetYAMLStyle(child)
+	}
+}
@@ -0,0 +1,165 @@
+package commands
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+)
+
+const redactedValue = "REDACTED"
+
+var sensitiveHeaders = []string{
+	"Authorization",
+	"Proxy-Authorization",
+	"Cookie",
+	"Set-Cookie",
+}
+
+// sensitiveFields contains the names of json fields holding secrets, like the
+// passwords of servers and devices, kube configs and object storage keys.
+var sensitiveFields = map[string]bool{
+	"password":      true,
+	"secret_key":    true,
+	"private_key":   true,
+	"kube_config":   true,
+	"token":         true,
+	"access_token":  true,
+	"refresh_token": true,
+	"client_secret": true,
+}
+
+func isSensitiveField(name string) bool {
+	name = strings.ToLower(name)
+	return sensitiveFields[name] || strings.HasSuffix(name, "_password")
+}
+
+// redactRequest returns a copy of the request with all secrets redacted. The
+// body of the original request is replaced, so it can still be sent.
+func redactRequest(req *http.Request) (*http.Request, error) {
+	body, err := readBody(&req.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	redacted := redactBody(body)
+
+	clone := req.Clone(req.Context())
+	clone.Header = redactHeader(req.Header)
+	clone.Body = io.NopCloser(bytes.NewReader(redacted))
+	clone.ContentLength = int64(len(redacted))
+
+	if body == nil {
+		clone.Body = nil
+		clone.ContentLength = 0
+	}
+
+	return clone, nil
+}
+
+// redactResponse returns a copy of the response with all secrets redacted.
+// The body of the original response is replaced, so it can still be read.
+func redactResponse(res *http.Response) (*http.Response, error) {
+	body, err := readBody(&res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	redacted := redactBody(body)
+
+	clone := *res
+	clone.Header = redactHeader(res.Header)
+	clone.Body = io.NopCloser(bytes.NewReader(redacted))
+	clone.ContentLength = int64(len(redacted))
+	clone.TransferEncoding = nil
+
+	return &clone, nil
+}
+
+// readBody reads the complete body and replaces it with an in-memory copy. A
+// missing body results in a nil slice.
+func readBody(body *io.ReadCloser) ([]byte, error) {
+	if *body == nil || *body == http.NoBody {
+		return nil, nil
+	}
+
+	data, err := io.ReadAll(*body)
+	if err != nil {
+		return nil, err
+	}
+
+	_ = (*body).Close()
+	*body = io.NopCloser(bytes.NewReader(data))
+	return data, nil
+}
+
+// redactHeader returns a copy of the header with the values of all sensitive
+// headers replaced.
+func redactHeader(header http.Header) http.Header {
+	res := header.Clone()
+
+	for _, name := range sensitiveHeaders {
+		if len(res.Values(name)) != 0 {
+			res.Set(name, redactedValue)
+		}
+	}
+
+	return res
+}
+
+// redactBody replaces the values of all sensitive fields in a json body. Bodies
+// which are not valid json are returned unchanged.
+func redactBody(body []byte) []byte {
+	if len(bytes.TrimSpace(body)) == 0 {
+		return body
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return body
+	}
+
+	if !redactValue(value) {
+		return body
+	}
+
+	res, err := json.Marshal(value)
+	if err != nil {
+		return body
+	}
+
+	return res
+}
+
+func redactValue(value interface{}) bool {
+	redacted := false
+
+	switch value := value.(type) {
+	case map[string]interface{}:
+		for key, child := range value {
+			// only strings are replaced to keep the shape of the json, e.g. the
+			// kube_config of a cluster is an object containing its expiry
+			if str, ok := child.(string); ok && str != "" && isSensitiveField(key) {
+				value[key] = redactedValue
+				redacted = true
+				continue
+			}
+
+			if redactValue(child) {
+				redacted = true
+			}
+		}
+	case []interface{}:
+		for _, child := range value {
+			if redactValue(child) {
+				redacted = true
+			}
+		}
+	}
+
+	return redacted
+}
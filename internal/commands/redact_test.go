@@ -0,0 +1,183 @@
+package commands
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/flowswiss/cli/v2/pkg/console"
+)
+
+func TestRedactHeader(t *testing.T) {
+	header := http.Header{}
+	header.Set("Authorization", "Bearer secret-token")
+	header.Set("Proxy-Authorization", "Basic secret-proxy")
+	header.Add("Set-Cookie", "session=secret-session")
+	header.Add("Set-Cookie", "other=secret-other")
+	header.Set("Content-Type", "application/json")
+
+	redacted := redactHeader(header)
+
+	expected := http.Header{
+		"Authorization":       {redactedValue},
+		"Proxy-Authorization": {redactedValue},
+		"Set-Cookie":          {redactedValue},
+		"Content-Type":        {"application/json"},
+	}
+
+	if !reflect.DeepEqual(redacted, expected) {
+		t.Errorf("expected %v, got %v", expected, redacted)
+	}
+
+	if header.Get("Authorization") != "Bearer secret-token" {
+		t.Errorf("original header was modified: %v", header)
+	}
+}
+
+func TestRedactBody(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		expected string
+	}{
+		{
+			name:     "password",
+			body:     `{"name": "my-server", "password": "secret"}`,
+			expected: `{"name": "my-server", "password": "REDACTED"}`,
+		},
+		{
+			name:     "suffixed password",
+			body:     `{"windows_password": "secret", "password_hint": "visible"}`,
+			expected: `{"windows_password": "REDACTED", "password_hint": "visible"}`,
+		},
+		{
+			name:     "object storage keys",
+			body:     `[{"access_key": "visible", "secret_key": "secret"}]`,
+			expected: `[{"access_key": "visible", "secret_key": "REDACTED"}]`,
+		},
+		{
+			name:     "kube config string",
+			body:     `{"kube_config": "apiVersion: v1"}`,
+			expected: `{"kube_config": "REDACTED"}`,
+		},
+		{
+			name:     "kube config object keeps its shape",
+			body:     `{"id": 1, "kube_config": {"expires_at": "2023-01-01T00:00:00+00:00"}}`,
+			expected: `{"id": 1, "kube_config": {"expires_at": "2023-01-01T00:00:00+00:00"}}`,
+		},
+		{
+			name:     "nested token",
+			body:     `{"items": [{"auth": {"Token": "secret"}}]}`,
+			expected: `{"items": [{"auth": {"Token": "REDACTED"}}]}`,
+		},
+		{
+			name:     "empty and null values",
+			body:     `{"password": "", "secret_key": null}`,
+			expected: `{"password": "", "secret_key": null}`,
+		},
+		{
+			name:     "large numbers",
+			body:     `{"id": 12345678901234567890, "password": "secret"}`,
+			expected: `{"id": 12345678901234567890, "password": "REDACTED"}`,
+		},
+		{
+			name:     "invalid json",
+			body:     `password=secret`,
+			expected: `password=secret`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			redacted := redactBody([]byte(tt.body))
+
+			var actual, expected interface{}
+			if err := json.Unmarshal(redacted, &actual); err != nil {
+				if string(redacted) != tt.expected {
+					t.Errorf("expected %s, got %s", tt.expected, redacted)
+				}
+				return
+			}
+
+			if err := json.Unmarshal([]byte(tt.expected), &expected); err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(actual, expected) {
+				t.Errorf("expected %s, got %s", tt.expected, redacted)
+			}
+		})
+	}
+}
+
+func TestDumpRequestTransport(t *testing.T) {
+	secrets := []string{"secret-token", "secret-password", "secret-key", "secret-kube-config"}
+
+	server := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		res.Header().Set("Content-Type", "application/json")
+		_, _ = res.Write([]byte(`{"secret_key": "secret-key", "kube_config": "secret-kube-config"}`))
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name       string
+		unredacted bool
+	}{
+		{name: "redacted", unredacted: false},
+		{name: "unredacted", unredacted: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output := filepath.Join(t.TempDir(), "dump")
+			file, err := os.Create(output)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer file.Close()
+
+			stderr := Stderr
+			Stderr = console.NewConsoleOutput(file)
+			defer func() { Stderr = stderr }()
+
+			req, err := http.NewRequest(http.MethodPost, server.URL+"/v4/compute/instances", strings.NewReader(`{"password": "secret-password"}`))
+			if err != nil {
+				t.Fatal(err)
+			}
+			req.Header.Set("Authorization", "Bearer secret-token")
+
+			res, err := dumpRequestTransport{unredacted: tt.unredacted}.RoundTrip(req)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			defer res.Body.Close()
+
+			// the response must not be affected by the redaction
+			body, err := io.ReadAll(res.Body)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !strings.Contains(string(body), "secret-kube-config") {
+				t.Errorf("expected unredacted response body, got %s", body)
+			}
+
+			dumped, err := os.ReadFile(output)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for _, secret := range secrets {
+				if contains := strings.Contains(string(dumped), secret); contains != tt.unredacted {
+					t.Errorf("expected dump to contain %q: %v, got:\n%s", secret, tt.unredacted, dumped)
+				}
+			}
+		})
+	}
+}
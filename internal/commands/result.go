@@ -0,0 +1,77 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ResultPrinter prints the results of an operation performed on multiple
+// items. Using the ndjson format, every result is printed as soon as it is
+// added. All other formats print the collected results on Flush.
+type ResultPrinter[T any] struct {
+	results []T
+}
+
+func (r *ResultPrinter[T]) Add(result T) error {
+	if streamingOutput() {
+		return encodeNDJSON(Stdout, result)
+	}
+
+	r.results = append(r.results, result)
+	return nil
+}
+
+func (r *ResultPrinter[T]) Flush() error {
+	if streamingOutput() || len(r.results) == 0 {
+		return nil
+	}
+
+	if len(r.results) == 1 {
+		return PrintStdout(r.results[0])
+	}
+
+	return PrintStdout(r.results)
+}
+
+// DeleteResult describes the outcome of deleting a single item.
+type DeleteResult struct {
+	Kind    string `json:"kind"`
+	ID      int    `json:"id,omitempty"`
+	Name    string `json:"name"`
+	Deleted bool   `json:"deleted"`
+	Error   string `json:"error,omitempty"`
+}
+
+// ForEach calls fn for every argument, continuing with the remaining
+// arguments if one of them fails. Failures are reported on stderr and
+// summarized in the returned error.
+func ForEach(args []string, fn func(arg string) error) error {
+	if len(args) == 1 {
+		return fn(args[0])
+	}
+
+	failed, planned := 0, false
+	for _, arg := range args {
+		err := fn(arg)
+		if errors.Is(err, ErrDryRun) {
+			// continue with the remaining arguments to report all planned requests
+			planned = true
+			continue
+		}
+
+		if err != nil {
+			Stderr.Errorf("%s: %v\n", arg, err)
+			failed++
+		}
+	}
+
+	if failed != 0 {
+		return fmt.Errorf("%d of %d operations failed", failed, len(args))
+	}
+
+	if planned {
+		return ErrDryRun
+	}
+
+	return nil
+}
@@ -2,9 +2,13 @@ package commands
 
 import (
 	"context"
+	"errors"
 	"os"
+	"os/signal"
+	"syscall"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 
 	"github.com/flowswiss/cli/v2/pkg/console"
 )
@@ -28,11 +32,42 @@ type Application struct {
 }
 
 func Run(app Application) {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	go func() {
+		// restore the default behaviour so a second signal terminates immediately
+		<-ctx.Done()
+		stop()
+	}()
+
+	cancelTimeout := context.CancelFunc(func() {})
+
 	root := cobra.Command{
 		Use:           app.Name,
 		Short:         app.Description,
 		Version:       app.Version,
 		SilenceErrors: true,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			if err := loadConfig(app, cmd, args); err != nil {
+				cmd.SilenceUsage = true
+				return err
+			}
+
+			// the dry run error is expected and must not print the usage
+			if viper.GetBool(FlagDryRun) {
+				cmd.SilenceUsage = true
+			}
+
+			if timeout := viper.GetDuration(FlagTimeout); timeout > 0 {
+				var timeoutCtx context.Context
+				timeoutCtx, cancelTimeout = context.WithTimeout(cmd.Context(), timeout)
+				cmd.SetContext(timeoutCtx)
+			}
+
+			silenceUsageOnCancel(cmd)
+			return nil
+		},
 	}
 
 	for _, module := range app.Modules {
@@ -41,13 +76,34 @@ func Run(app Application) {
 	}
 
 	setupFlags(app, &root)
-	cobra.OnInitialize(func() {
-		loadConfig(app)
-	})
 
-	err := root.ExecuteContext(context.Background())
+	err := root.ExecuteContext(ctx)
+	cancelTimeout()
+
+	if errors.Is(err, ErrDryRun) || (err == nil && hasPlan()) {
+		err = printPlan()
+	}
+
 	if err != nil {
 		Stderr.Errorf("%v\n", err)
 		os.Exit(1)
 	}
 }
+
+// silenceUsageOnCancel prevents the usage from being printed when the command
+// fails because it got interrupted or timed out.
+func silenceUsageOnCancel(cmd *cobra.Command) {
+	run := cmd.RunE
+	if run == nil {
+		return
+	}
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		err := run(cmd, args)
+		if err != nil && cmd.Context().Err() != nil {
+			cmd.SilenceUsage = true
+		}
+
+		return err
+	}
+}
@@ -0,0 +1,105 @@
+package commands
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"sync"
+
+	"github.com/spf13/viper"
+)
+
+var (
+	tokenCache      = map[string]string{}
+	tokenCacheMutex sync.Mutex
+)
+
+// Token returns the configured authentication token. The token is either
+// configured directly, read from the token file or printed to stdout by the
+// token command. Tokens from files and commands are cached for the lifetime
+// of the process. A token source of the active profile replaces the top-level
+// ones, see loadProfile.
+func Token() (string, error) {
+	if token := viper.GetString(FlagToken); len(token) != 0 {
+		return token, nil
+	}
+
+	if path := viper.GetString(FlagTokenFile); len(path) != 0 {
+		return cachedToken("file:"+path, func() (string, error) {
+			return readTokenFile(path)
+		})
+	}
+
+	if command := viper.GetString(FlagTokenCommand); len(command) != 0 {
+		return cachedToken("command:"+command, func() (string, error) {
+			return runTokenCommand(command)
+		})
+	}
+
+	return "", nil
+}
+
+func cachedToken(key string, resolve func() (string, error)) (string, error) {
+	tokenCacheMutex.Lock()
+	defer tokenCacheMutex.Unlock()
+
+	if token, ok := tokenCache[key]; ok {
+		return token, nil
+	}
+
+	token, err := resolve()
+	if err != nil {
+		return "", err
+	}
+
+	tokenCache[key] = token
+	return token, nil
+}
+
+func readTokenFile(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("read token file: %w", err)
+	}
+
+	token := strings.TrimSpace(string(data))
+	if len(token) == 0 {
+		return "", fmt.Errorf("token file %q is empty", path)
+	}
+
+	return token, nil
+}
+
+func runTokenCommand(command string) (string, error) {
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.Command("cmd", "/C", command)
+	} else {
+		cmd = exec.Command("sh", "-c", command)
+	}
+
+	stderr := &bytes.Buffer{}
+	cmd.Stdin = os.Stdin
+	cmd.Stderr = stderr
+
+	out, err := cmd.Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && stderr.Len() != 0 {
+			return "", fmt.Errorf("token command %q failed: %w: %s", command, err, strings.TrimSpace(stderr.String()))
+		}
+
+		return "", fmt.Errorf("token command %q failed: %w", command, err)
+	}
+
+	token := strings.TrimSpace(string(out))
+	if len(token) == 0 {
+		return "", fmt.Errorf("token command %q did not print a token", command)
+	}
+
+	return token, nil
+}
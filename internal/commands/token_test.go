@@ -0,0 +1,185 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+)
+
+func TestToken_ProfilePrecedence(t *testing.T) {
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(tokenFile, []byte("file-token\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		config   map[string]interface{}
+		flag     string
+		expected string
+	}{
+		{
+			name: "top-level token without profile",
+			config: map[string]interface{}{
+				FlagToken: "top-level-token",
+			},
+			expected: "top-level-token",
+		},
+		{
+			name: "profile inherits top-level token",
+			config: map[string]interface{}{
+				FlagToken:         "top-level-token",
+				KeyCurrentContext: "test",
+				KeyContexts: map[string]interface{}{
+					"test": map[string]interface{}{KeyLocation: "ALP1"},
+				},
+			},
+			expected: "top-level-token",
+		},
+		{
+			name: "profile token overrides top-level token",
+			config: map[string]interface{}{
+				FlagToken:         "top-level-token",
+				KeyCurrentContext: "test",
+				KeyContexts: map[string]interface{}{
+					"test": map[string]interface{}{FlagToken: "profile-token"},
+				},
+			},
+			expected: "profile-token",
+		},
+		{
+			name: "profile token file overrides top-level token",
+			config: map[string]interface{}{
+				FlagToken:         "top-level-token",
+				KeyCurrentContext: "test",
+				KeyContexts: map[string]interface{}{
+					"test": map[string]interface{}{FlagTokenFile: tokenFile},
+				},
+			},
+			expected: "file-token",
+		},
+		{
+			name: "profile token command overrides top-level token file",
+			config: map[string]interface{}{
+				FlagTokenFile:     tokenFile,
+				KeyCurrentContext: "test",
+				KeyContexts: map[string]interface{}{
+					"test": map[string]interface{}{FlagTokenCommand: "echo command-token"},
+				},
+			},
+			expected: "command-token",
+		},
+		{
+			name: "token flag overrides profile token file",
+			config: map[string]interface{}{
+				KeyCurrentContext: "test",
+				KeyContexts: map[string]interface{}{
+					"test": map[string]interface{}{FlagTokenFile: tokenFile},
+				},
+			},
+			flag:     "flag-token",
+			expected: "flag-token",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer viper.Reset()
+
+			data, err := json.Marshal(tt.config)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			viper.SetConfigType("json")
+			if err := viper.ReadConfig(strings.NewReader(string(data))); err != nil {
+				t.Fatal(err)
+			}
+
+			flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+			flags.String(FlagToken, "", "")
+			if len(tt.flag) != 0 {
+				_ = flags.Set(FlagToken, tt.flag)
+			}
+
+			if err := viper.BindPFlags(flags); err != nil {
+				t.Fatal(err)
+			}
+
+			if _, err := loadProfile(); err != nil {
+				t.Fatalf("load profile: %v", err)
+			}
+
+			token, err := Token()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if token != tt.expected {
+				t.Errorf("expected token %q, got %q", tt.expected, token)
+			}
+		})
+	}
+}
+
+func TestLoadConfig_SkipAuthentication(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		executed bool
+	}{
+		{name: "authenticated command", args: []string{"get"}, executed: true},
+		{name: "skip authentication", args: []string{"config", "view"}, executed: false},
+		{name: "completion of authenticated command", args: []string{cobra.ShellCompRequestCmd, "get", ""}, executed: true},
+		{name: "completion of skip authentication", args: []string{cobra.ShellCompRequestCmd, "config", "view", ""}, executed: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer viper.Reset()
+
+			dir := configDir
+			configDir = t.TempDir()
+			defer func() { configDir = dir }()
+
+			marker := filepath.Join(t.TempDir(), "executed")
+			t.Setenv("FLOW_TOKEN_COMMAND", fmt.Sprintf("echo command-token > %s", marker))
+
+			app := Application{Name: "flow", Version: "test"}
+			root := newTestRoot(app,
+				&cobra.Command{
+					Use: "get",
+					Run: func(cmd *cobra.Command, args []string) {},
+				},
+				&cobra.Command{
+					Use:         "config",
+					Annotations: map[string]string{AnnotationSkipAuthentication: ""},
+				},
+			)
+			root.Commands()[0].AddCommand(&cobra.Command{
+				Use: "view",
+				Run: func(cmd *cobra.Command, args []string) {},
+			})
+
+			root.SetOut(io.Discard)
+			root.SetErr(io.Discard)
+			root.SetArgs(tt.args)
+
+			// the token command does not print the token to stdout, so the
+			// authenticated commands fail with a missing token
+			_ = root.Execute()
+
+			if _, err := os.Stat(marker); (err == nil) != tt.executed {
+				t.Errorf("expected token command to be executed: %v, got error %v", tt.executed, err)
+			}
+		})
+	}
+}
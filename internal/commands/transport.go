@@ -1,32 +1,38 @@
 package commands
 
 import (
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net"
 	"net/http"
 	"net/http/httputil"
+	"strconv"
+	"sync"
+	"time"
 
 	"github.com/flowswiss/cli/v2/pkg/console"
 )
 
 var _ http.RoundTripper = (*dryRunTransport)(nil)
 
+// dryRunTransport sends read-only requests as usual, but records modifying
+// requests in the plan and aborts them with ErrDryRun.
 type dryRunTransport struct {
 	delegate http.RoundTripper
 }
 
 func (d dryRunTransport) RoundTrip(req *http.Request) (*http.Response, error) {
-	if req.Method == http.MethodGet {
+	if req.Method == http.MethodGet || req.Method == http.MethodHead {
 		return d.base().RoundTrip(req)
 	}
 
-	Stderr.Color(console.Bright + console.Black)
-	_ = req.Write(Stderr)
-	Stderr.Reset()
+	if err := recordPlannedRequest(req); err != nil {
+		return nil, err
+	}
 
-	return &http.Response{
-		Status:     "200 OK",
-		StatusCode: http.StatusOK,
-		Body:       http.NoBody,
-	}, nil
+	return nil, ErrDryRun
 }
 
 func (d dryRunTransport) base() http.RoundTripper {
@@ -44,14 +50,24 @@ type logRequestTransport struct {
 }
 
 func (l logRequestTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
 	res, err := l.base().RoundTrip(req)
 
+	entry := LogEntry{
+		Message:   "request",
+		Method:    req.Method,
+		URL:       req.URL.String(),
+		LatencyMS: time.Since(start).Milliseconds(),
+	}
+
 	if err == nil {
-		Stderr.Color(console.Bright+console.Black).Printf("request to `%s %s` resulted in `%s`\n", req.Method, req.URL, res.Status).Reset()
+		entry.Status = res.StatusCode
+		entry.RequestID = res.Header.Get("X-Request-Id")
 	} else {
-		Stderr.Errorf("request to `%s %s` resulted in error `%v`\n", req.Method, req.URL, err)
+		entry.Error = err.Error()
 	}
 
+	Log.Print(LogLevelRequests, entry)
 	return res, err
 }
 
@@ -66,7 +82,8 @@ func (l logRequestTransport) base() http.RoundTripper {
 var _ http.RoundTripper = (*dumpRequestTransport)(nil)
 
 type dumpRequestTransport struct {
-	delegate http.RoundTripper
+	delegate   http.RoundTripper
+	unredacted bool
 }
 
 func (d dumpRequestTransport) RoundTrip(req *http.Request) (*http.Response, error) {
@@ -74,7 +91,15 @@ func (d dumpRequestTransport) RoundTrip(req *http.Request) (*http.Response, erro
 	defer Stderr.Reset()
 
 	// dump request
-	data, err := httputil.DumpRequestOut(req, true)
+	dumped := req
+	if !d.unredacted {
+		var err error
+		if dumped, err = redactRequest(req); err != nil {
+			return nil, err
+		}
+	}
+
+	data, err := httputil.DumpRequestOut(dumped, true)
 	if err != nil {
 		return nil, err
 	}
@@ -87,7 +112,14 @@ func (d dumpRequestTransport) RoundTrip(req *http.Request) (*http.Response, erro
 	}
 
 	// dump response
-	data, err = httputil.DumpResponse(res, true)
+	dumpedRes := res
+	if !d.unredacted {
+		if dumpedRes, err = redactResponse(res); err != nil {
+			return nil, err
+		}
+	}
+
+	data, err = httputil.DumpResponse(dumpedRes, true)
 	if err != nil {
 		return nil, err
 	}
@@ -106,3 +138,179 @@ func (d dumpRequestTransport) base() http.RoundTripper {
 
 	return d.delegate
 }
+
+const (
+	retryBaseDelay = 500 * time.Millisecond
+	retryMaxDelay  = 30 * time.Second
+)
+
+var (
+	retryRandom      = rand.New(rand.NewSource(time.Now().UnixNano()))
+	retryRandomMutex sync.Mutex
+)
+
+var _ http.RoundTripper = (*retryTransport)(nil)
+
+// retryTransport retries requests failing with transient errors. Idempotent
+// requests are retried on connection errors and 429, 502, 503 and 504
+// responses. All other requests are only retried on 429 responses, as the
+// server might have processed them already otherwise.
+type retryTransport struct {
+	delegate   http.RoundTripper
+	maxRetries int
+}
+
+func (r retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		attemptReq, err := r.rewind(req, attempt)
+		if err != nil {
+			return nil, err
+		}
+
+		res, err := r.base().RoundTrip(attemptReq)
+		if attempt >= r.maxRetries || !r.shouldRetry(req, res, err) {
+			return res, err
+		}
+
+		delay := retryDelay(attempt, res)
+
+		entry := LogEntry{
+			Message: fmt.Sprintf("retrying in %s", delay.Round(time.Millisecond)),
+			Method:  req.Method,
+			URL:     req.URL.String(),
+			Attempt: attempt + 1,
+		}
+
+		if err != nil {
+			entry.Error = err.Error()
+		} else {
+			entry.Status = res.StatusCode
+		}
+
+		Log.Print(LogLevelDebug, entry)
+
+		if res != nil {
+			_, _ = io.Copy(io.Discard, res.Body)
+			_ = res.Body.Close()
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// rewind returns a copy of the request with a fresh body for every retry.
+func (r retryTransport) rewind(req *http.Request, attempt int) (*http.Request, error) {
+	if attempt == 0 || req.Body == nil || req.Body == http.NoBody {
+		return req, nil
+	}
+
+	if req.GetBody == nil {
+		return nil, fmt.Errorf("unable to retry request without rewindable body")
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, err
+	}
+
+	clone := req.Clone(req.Context())
+	clone.Body = body
+	return clone, nil
+}
+
+func (r retryTransport) shouldRetry(req *http.Request, res *http.Response, err error) bool {
+	if req.Context().Err() != nil {
+		return false
+	}
+
+	if err != nil {
+		return isIdempotent(req.Method) && isConnectionError(err)
+	}
+
+	switch res.StatusCode {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return isIdempotent(req.Method)
+	}
+
+	return false
+}
+
+func (r retryTransport) base() http.RoundTripper {
+	if r.delegate == nil {
+		return http.DefaultTransport
+	}
+
+	return r.delegate
+}
+
+// isConnectionError reports whether the error was caused by the network
+// rather than by the client itself, e.g. in dry run or replay mode.
+func isConnectionError(err error) bool {
+	var netErr net.Error
+	return errors.As(err, &netErr) || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+
+	return false
+}
+
+// retryDelay returns the delay before the next attempt. The delay requested
+// by the server using the Retry-After header takes precedence over the
+// exponential backoff, but is limited to retryMaxDelay as well.
+func retryDelay(attempt int, res *http.Response) time.Duration {
+	if res != nil {
+		if delay, ok := parseRetryAfter(res.Header.Get("Retry-After")); ok {
+			if delay > retryMaxDelay {
+				delay = retryMaxDelay
+			}
+
+			return delay
+		}
+	}
+
+	delay := retryBaseDelay << attempt
+	if delay <= 0 || delay > retryMaxDelay {
+		delay = retryMaxDelay
+	}
+
+	// jitter the delay to spread the retries of concurrent clients
+	retryRandomMutex.Lock()
+	jitter := time.Duration(retryRandom.Int63n(int64(delay/2) + 1))
+	retryRandomMutex.Unlock()
+
+	return delay/2 + jitter
+}
+
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if len(value) == 0 {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		delay := time.Until(date)
+		if delay < 0 {
+			delay = 0
+		}
+
+		return delay, true
+	}
+
+	return 0, false
+}
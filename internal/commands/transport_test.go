@@ -0,0 +1,131 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+func TestNewClient_RetryAuthorization(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		do     func(ctx context.Context, app Application) error
+	}{
+		{
+			name:   "get without body",
+			status: http.StatusServiceUnavailable,
+			do: func(ctx context.Context, app Application) error {
+				var res map[string]interface{}
+				return NewClient(app, "secret").Get(ctx, "/test", &res)
+			},
+		},
+		{
+			name:   "post with body",
+			status: http.StatusTooManyRequests,
+			do: func(ctx context.Context, app Application) error {
+				var res map[string]interface{}
+				return NewClient(app, "secret").Create(ctx, "/test", map[string]string{"name": "test"}, &res)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mu sync.Mutex
+			var headers [][]string
+
+			server := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+				mu.Lock()
+				defer mu.Unlock()
+
+				headers = append(headers, req.Header.Values("Authorization"))
+				if len(headers) == 1 {
+					res.Header().Set("Retry-After", "0")
+					res.WriteHeader(tt.status)
+					return
+				}
+
+				res.Header().Set("Content-Type", "application/json")
+				_, _ = res.Write([]byte("{}"))
+			}))
+			defer server.Close()
+
+			defer viper.Reset()
+			viper.Set(FlagEndpoint, server.URL)
+			viper.Set(FlagMaxRetries, 2)
+			viper.Set(FlagNoCache, true)
+
+			if err := tt.do(context.Background(), Application{Name: "flow", Version: "test"}); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			expected := [][]string{{"Bearer secret"}, {"Bearer secret"}}
+			if !reflect.DeepEqual(headers, expected) {
+				t.Errorf("expected authorization headers %q, got %q", expected, headers)
+			}
+		})
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	tests := []struct {
+		name       string
+		retryAfter string
+		min, max   time.Duration
+	}{
+		{name: "without header", retryAfter: "", min: retryBaseDelay / 2, max: retryBaseDelay},
+		{name: "seconds", retryAfter: "2", min: 2 * time.Second, max: 2 * time.Second},
+		{name: "limited seconds", retryAfter: "86400", min: retryMaxDelay, max: retryMaxDelay},
+		{name: "limited date", retryAfter: time.Now().Add(24 * time.Hour).UTC().Format(http.TimeFormat), min: retryMaxDelay, max: retryMaxDelay},
+		{name: "past date", retryAfter: time.Now().Add(-time.Hour).UTC().Format(http.TimeFormat), min: 0, max: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := &http.Response{Header: http.Header{}}
+			if len(tt.retryAfter) != 0 {
+				res.Header.Set("Retry-After", tt.retryAfter)
+			}
+
+			delay := retryDelay(0, res)
+			if delay < tt.min || delay > tt.max {
+				t.Errorf("expected delay between %s and %s, got %s", tt.min, tt.max, delay)
+			}
+		})
+	}
+}
+
+func TestRetryTransport_Context(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		res.Header().Set("Retry-After", "86400")
+		res.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Now()
+
+	_, err = retryTransport{maxRetries: 3}.RoundTrip(req)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected the deadline to be exceeded, got %v", err)
+	}
+
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("expected the retry to stop with the context, took %s", elapsed)
+	}
+}
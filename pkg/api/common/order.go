@@ -2,17 +2,136 @@ package common
 
 import (
 	"context"
+	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/flowswiss/goclient"
 	"github.com/flowswiss/goclient/common"
+
+	"github.com/flowswiss/cli/v2/pkg/console"
+	"github.com/flowswiss/cli/v2/pkg/filter"
 )
 
 var ErrOrderFailed = common.ErrOrderFailed
 
+const (
+	OrderStatusCreated    = common.OrderStatusCreated
+	OrderStatusProcessing = common.OrderStatusProcessing
+	OrderStatusSucceeded  = common.OrderStatusSucceeded
+	OrderStatusFailed     = common.OrderStatusFailed
+)
+
+var (
+	_ filter.Filterable   = (*Order)(nil)
+	_ console.Displayable = (*Order)(nil)
+	_ console.Displayable = (*OrderReference)(nil)
+)
+
 type Ordering = common.Ordering
 
-type Order = common.Order
+type Order common.Order
+
+func (o Order) String() string {
+	return fmt.Sprint(o.ID)
+}
+
+func (o Order) Keys() []string {
+	return []string{fmt.Sprint(o.ID), o.Status.Name}
+}
+
+func (o Order) Columns() []string {
+	return []string{"id", "status", "product instance", "created at"}
+}
+
+func (o Order) Values() map[string]interface{} {
+	productInstance := ""
+	if o.Product.ID != 0 {
+		productInstance = fmt.Sprint(o.Product.ID)
+	}
+
+	return map[string]interface{}{
+		"id":               o.ID,
+		"status":           o.Status.Name,
+		"product instance": productInstance,
+		"created at":       o.CreatedAt,
+	}
+}
+
+// Processed reports whether the order has either succeeded or failed.
+func (o Order) Processed() bool {
+	return o.Status.ID == OrderStatusSucceeded || o.Status.ID == OrderStatusFailed
+}
+
+// OrderReference identifies an order which has been placed, but is not
+// necessarily processed yet.
+type OrderReference struct {
+	ID  int    `json:"id"`
+	Ref string `json:"ref"`
+}
+
+func NewOrderReference(ordering Ordering) (OrderReference, error) {
+	id, err := ordering.ExtractIdentifier()
+	if err != nil {
+		return OrderReference{}, fmt.Errorf("extract ordering identifier: %w", err)
+	}
+
+	return OrderReference{ID: id, Ref: ordering.Ref}, nil
+}
+
+// ParseOrderReference accepts either the numeric id of an order or its
+// reference as returned when placing the order (e.g. /v4/orders/42).
+func ParseOrderReference(term string) (OrderReference, error) {
+	if id, err := strconv.Atoi(term); err == nil {
+		return OrderReference{ID: id, Ref: goclient.Join(ordersSegment, id)}, nil
+	}
+
+	ref, err := NewOrderReference(Ordering{Ref: strings.TrimSuffix(term, "/")})
+	if err != nil {
+		return OrderReference{}, fmt.Errorf("invalid order %q, expected an id or a reference like %s/42", term, ordersSegment)
+	}
+
+	return ref, nil
+}
+
+func (o OrderReference) Ordering() Ordering {
+	return Ordering{Ref: o.Ref}
+}
+
+func (o OrderReference) String() string {
+	return fmt.Sprint(o.ID)
+}
+
+func (o OrderReference) Columns() []string {
+	return []string{"id", "ref"}
+}
+
+func (o OrderReference) Values() map[string]interface{} {
+	return map[string]interface{}{
+		"id":  o.ID,
+		"ref": o.Ref,
+	}
+}
+
+const ordersSegment = "/v4/orders"
+
+func Orders(ctx context.Context, client goclient.Client) ([]Order, error) {
+	var items []Order
+
+	_, err := client.List(ctx, ordersSegment, goclient.Cursor{NoFilter: 1}, &items)
+	if err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
+
+func GetOrder(ctx context.Context, client goclient.Client, id int) (Order, error) {
+	order, err := common.NewOrderService(client).Get(ctx, id)
+	return Order(order), err
+}
 
 func WaitForOrder(ctx context.Context, client goclient.Client, ordering Ordering) (Order, error) {
-	return common.NewOrderService(client).WaitUntilProcessed(ctx, ordering)
+	order, err := common.NewOrderService(client).WaitUntilProcessed(ctx, ordering)
+	return Order(order), err
 }
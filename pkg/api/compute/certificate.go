@@ -25,6 +25,10 @@ func (c Certificate) Columns() []string {
 	return []string{"id", "name", "location", "valid from", "valid to", "serial", "subject", "issuer"}
 }
 
+func (c Certificate) CompactColumns() []string {
+	return []string{"id", "name", "location", "valid to", "subject"}
+}
+
 func (c Certificate) Values() map[string]interface{} {
 	subjectBuffer := strings.Builder{}
 	for key, value := range c.Details.Subject {
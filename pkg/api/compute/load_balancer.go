@@ -25,6 +25,10 @@ func (l LoadBalancer) Columns() []string {
 	return []string{"id", "name", "location", "product", "status", "public ip", "network"}
 }
 
+func (l LoadBalancer) CompactColumns() []string {
+	return []string{"id", "name", "location", "status", "public ip"}
+}
+
 func (l LoadBalancer) Values() map[string]interface{} {
 	networkBuffer := &strings.Builder{}
 	publicIPBuffer := &strings.Builder{}
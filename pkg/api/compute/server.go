@@ -33,6 +33,10 @@ func (s Server) Columns() []string {
 	return []string{"id", "name", "status", "product", "operating system", "location", "public ip", "network"}
 }
 
+func (s Server) CompactColumns() []string {
+	return []string{"id", "name", "status", "product", "location", "public ip"}
+}
+
 func (s Server) Values() map[string]interface{} {
 	networkBuffer := &strings.Builder{}
 	publicIPBuffer := &strings.Builder{}
@@ -25,6 +25,10 @@ func (c Cluster) Columns() []string {
 	return []string{"id", "name", "status", "product", "location", "network", "address", "control plane", "worker"}
 }
 
+func (c Cluster) CompactColumns() []string {
+	return []string{"id", "name", "status", "location", "address", "worker"}
+}
+
 func (c Cluster) Values() map[string]interface{} {
 	return map[string]interface{}{
 		"id":            c.ID,
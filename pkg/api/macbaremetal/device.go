@@ -25,6 +25,10 @@ func (d Device) Columns() []string {
 	return []string{"id", "name", "location", "product", "operating system", "public ip", "network", "hostname", "status"}
 }
 
+func (d Device) CompactColumns() []string {
+	return []string{"id", "name", "location", "product", "public ip", "status"}
+}
+
 func (d Device) Values() map[string]interface{} {
 	networkBuffer := &bytes.Buffer{}
 	publicIPBuffer := &bytes.Buffer{}
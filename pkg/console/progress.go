@@ -1,62 +1,208 @@
 package console
 
 import (
+	"fmt"
 	"sync"
 	"time"
 )
 
-// TODO refactor
+const (
+	progressRefreshInterval = 200 * time.Millisecond
+	progressLogInterval     = 10 * time.Second
+)
+
+var spinnerChars = []rune{'|', '/', '-', '\\'}
 
+// Progress displays the status and elapsed time of one or more concurrently
+// running tasks. On a terminal all tasks are rendered as continuously updated
+// lines, otherwise timestamped log lines are printed periodically.
 type Progress struct {
-	message string
+	out  Writer
+	ansi bool
+
+	mu    sync.Mutex
+	tasks []*Task
+	lines int
+	frame int
 
 	done chan struct{}
 	wg   sync.WaitGroup
 }
 
-func NewProgress(message string) *Progress {
+// Task is a single unit of work tracked by a Progress.
+type Task struct {
+	progress *Progress
+	message  string
+
+	started  time.Time
+	finished time.Time
+	err      error
+}
+
+func NewProgress(out Writer) *Progress {
+	_, ansi := out.(ansiWriter)
+
 	return &Progress{
-		message: message,
-		done:    make(chan struct{}),
-		wg:      sync.WaitGroup{},
+		out:  out,
+		ansi: ansi,
+		done: make(chan struct{}),
 	}
 }
 
-func (p *Progress) Done() {
+// Add registers a new running task and starts measuring its elapsed time.
+func (p *Progress) Add(message string) *Task {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	task := &Task{
+		progress: p,
+		message:  message,
+		started:  time.Now(),
+	}
+
+	p.tasks = append(p.tasks, task)
+	if !p.ansi {
+		p.logTask(task)
+	}
+
+	return task
+}
+
+// Start renders the progress in the background until Stop is called.
+func (p *Progress) Start() {
+	p.wg.Add(1)
+	go p.run()
+}
+
+// Stop stops rendering and prints the final status of all tasks. Nothing else
+// should be written to the output between Start and Stop.
+func (p *Progress) Stop() {
 	close(p.done)
 	p.wg.Wait()
+
+	if p.ansi {
+		p.mu.Lock()
+		defer p.mu.Unlock()
+
+		p.renderAnsi()
+		p.out.Print("\u001B[?25h") // show cursor
+	}
 }
 
-func (p *Progress) displayAnsi(out Writer) {
-	ticker := time.NewTicker(200 * time.Millisecond)
-	defer ticker.Stop()
+func (p *Progress) run() {
+	defer p.wg.Done()
+
+	interval := progressLogInterval
+	if p.ansi {
+		interval = progressRefreshInterval
+		p.out.Print("\u001B[?25l") // hide cursor
+	}
 
-	chars := []rune{'|', '/', '-', '\\'}
-	idx := 0
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
 
-	out.Print("\u001B[s") // save current cursor position
 	for {
-		out.Print("\u001B[u\u001B[0K") // restore cursor position and clear line
-		out.Printf("[%s] %s\n", string(chars[idx]), p.message)
-		idx = (idx + 1) % len(chars)
+		p.mu.Lock()
+		if p.ansi {
+			p.renderAnsi()
+		} else {
+			p.logRunning()
+		}
+		p.mu.Unlock()
 
 		select {
 		case <-ticker.C:
 		case <-p.done:
-			out.Print("\u001B[u\u001B[0K") // restore cursor position and clear line
 			return
 		}
 	}
 }
 
-func (p *Progress) Display(out Writer) {
-	p.wg.Add(1)
-	defer p.wg.Done()
+// renderAnsi redraws the line of every task in place. The caller must hold
+// the lock.
+func (p *Progress) renderAnsi() {
+	if p.lines > 0 {
+		p.out.Printf("\u001B[%dA", p.lines) // move cursor to the first line
+	}
+
+	for _, task := range p.tasks {
+		p.out.Print("\r\u001B[2K") // clear line
+
+		switch {
+		case task.finished.IsZero():
+			p.out.Printf("[%c] %s (%s)\n", spinnerChars[p.frame%len(spinnerChars)], task.message, formatElapsed(task.Elapsed()))
+		case task.err != nil:
+			p.out.Color(Red).Print("[x]").Reset().Printf(" %s (%s): %v\n", task.message, formatElapsed(task.Elapsed()), task.err)
+		default:
+			p.out.Color(Green).Print("[+]").Reset().Printf(" %s (%s)\n", task.message, formatElapsed(task.Elapsed()))
+		}
+	}
+
+	p.lines = len(p.tasks)
+	p.frame++
+}
+
+// logRunning prints a log line for every task which is still running. The
+// caller must hold the lock.
+func (p *Progress) logRunning() {
+	for _, task := range p.tasks {
+		if task.finished.IsZero() && time.Since(task.started) >= progressLogInterval {
+			p.logTask(task)
+		}
+	}
+}
+
+// logTask prints a timestamped log line with the status of the task. The
+// caller must hold the lock.
+func (p *Progress) logTask(task *Task) {
+	timestamp := time.Now().Format(time.RFC3339)
+
+	switch {
+	case task.finished.IsZero() && task.Elapsed() < progressLogInterval:
+		p.out.Printf("%s %s\n", timestamp, task.message)
+	case task.finished.IsZero():
+		p.out.Printf("%s %s, still running after %s\n", timestamp, task.message, formatElapsed(task.Elapsed()))
+	case task.err != nil:
+		p.out.Errorf("%s %s, failed after %s: %v\n", timestamp, task.message, formatElapsed(task.Elapsed()), task.err)
+	default:
+		p.out.Printf("%s %s, done after %s\n", timestamp, task.message, formatElapsed(task.Elapsed()))
+	}
+}
+
+// Done marks the task as finished. A non-nil error reports the task as failed.
+func (t *Task) Done(err error) {
+	p := t.progress
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if !t.finished.IsZero() {
+		return
+	}
+
+	t.finished = time.Now()
+	t.err = err
+
+	if !p.ansi {
+		p.logTask(t)
+	}
+}
 
-	if _, ok := out.(ansiWriter); ok {
-		p.displayAnsi(out)
-	} else {
-		out.Printf("%s\n", p.message)
-		<-p.done
+// Elapsed returns the time since the task was added or the total duration of
+// the task once it is done.
+func (t *Task) Elapsed() time.Duration {
+	if t.finished.IsZero() {
+		return time.Since(t.started)
 	}
+
+	return t.finished.Sub(t.started)
+}
+
+func formatElapsed(d time.Duration) string {
+	d = d.Round(time.Second)
+	if d < time.Minute {
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	}
+
+	return fmt.Sprintf("%dm%02ds", int(d.Minutes()), int(d.Seconds())%60)
 }
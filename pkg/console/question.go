@@ -3,6 +3,7 @@ package console
 import (
 	"bufio"
 	"os"
+	"strconv"
 	"strings"
 
 	"golang.org/x/term"
@@ -26,6 +27,16 @@ func (c confirmOption) String() string {
 	return confirmOptionNames[c]
 }
 
+type selectOption int
+
+func (s selectOption) String() string {
+	if s == 0 {
+		return ""
+	}
+
+	return strconv.Itoa(int(s))
+}
+
 type optConstraint interface {
 	comparable
 	String() string
@@ -89,6 +100,24 @@ func Ask[T optConstraint](writer Writer, question string, opts ...T) (res T, err
 	return res, nil
 }
 
+// Select prints a numbered list of options and asks for one of them. It
+// returns the index of the selected option or -1 if no option was selected.
+func Select(writer Writer, question string, options []string) (int, error) {
+	opts := make([]selectOption, len(options))
+
+	for i, option := range options {
+		opts[i] = selectOption(i + 1)
+		writer.Printf("%3d) %s\n", i+1, option)
+	}
+
+	res, err := Ask(writer, question, opts...)
+	if err != nil {
+		return -1, err
+	}
+
+	return int(res) - 1, nil
+}
+
 func Password(writer Writer, prompt string, valid func(string) error) (string, error) {
 	for {
 		writer.Print(prompt)
@@ -0,0 +1,144 @@
+package console
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var leadingNumberRegex = regexp.MustCompile(`^\s*-?\d+(\.\d+)?`)
+
+// Sort sorts a slice of Displayable values in place by the given column.
+// Columns only containing numbers (or values starting with a number, like
+// prices) are sorted numerically, all other columns lexically. Values which
+// are not a slice are left untouched.
+func Sort(val interface{}, column string, reverse bool) error {
+	value := reflect.ValueOf(val)
+	if value.Kind() != reflect.Slice {
+		return nil
+	}
+
+	// the column is validated against the element type, so that an invalid
+	// column is reported for empty lists as well
+	columns, err := sortColumns(value)
+	if err != nil {
+		return err
+	}
+
+	if columns == nil {
+		return nil
+	}
+
+	name := findColumnName(columns, column)
+	if len(name) == 0 {
+		return unknownColumnError(column, columns)
+	}
+
+	if value.Len() == 0 {
+		return nil
+	}
+
+	items := make([]Displayable, value.Len())
+	for i := range items {
+		item, ok := value.Index(i).Interface().(Displayable)
+		if !ok {
+			return fmt.Errorf("unable to sort non `Displayable` values of type %q", value.Type().Elem().String())
+		}
+
+		items[i] = item
+	}
+
+	s := &sorter{
+		keys:    make([]string, len(items)),
+		numbers: make([]float64, len(items)),
+		numeric: true,
+		swap:    reflect.Swapper(val),
+		reverse: reverse,
+	}
+
+	for i, item := range items {
+		raw := item.Values()[name]
+		s.keys[i] = strings.ToLower(fmt.Sprintf("%+v", raw))
+
+		number, ok := numericValue(raw)
+		if !ok {
+			s.numeric = false
+		}
+
+		s.numbers[i] = number
+	}
+
+	sort.Stable(s)
+	return nil
+}
+
+// sortColumns returns the columns of the elements of the slice, preferring the
+// first element over the element type, e.g. for slices of interfaces.
+func sortColumns(value reflect.Value) ([]string, error) {
+	if value.Len() != 0 {
+		if item, ok := value.Index(0).Interface().(Displayable); ok {
+			return item.Columns(), nil
+		}
+	} else if displayable := elementDisplayable(value.Type()); displayable != nil {
+		return displayable.Columns(), nil
+	} else if value.Type().Elem().Kind() == reflect.Interface {
+		// the columns of an empty list of interfaces are unknown
+		return nil, nil
+	}
+
+	return nil, fmt.Errorf("unable to sort non `Displayable` values of type %q", value.Type().Elem().String())
+}
+
+func numericValue(raw interface{}) (float64, bool) {
+	value := reflect.ValueOf(raw)
+
+	switch value.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(value.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(value.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return value.Float(), true
+	}
+
+	match := leadingNumberRegex.FindString(fmt.Sprintf("%+v", raw))
+	if len(match) == 0 {
+		return 0, false
+	}
+
+	number, err := strconv.ParseFloat(strings.TrimSpace(match), 64)
+	return number, err == nil
+}
+
+type sorter struct {
+	keys    []string
+	numbers []float64
+	numeric bool
+	swap    func(i, j int)
+	reverse bool
+}
+
+func (s *sorter) Len() int {
+	return len(s.keys)
+}
+
+func (s *sorter) Less(i, j int) bool {
+	if s.reverse {
+		i, j = j, i
+	}
+
+	if s.numeric {
+		return s.numbers[i] < s.numbers[j]
+	}
+
+	return s.keys[i] < s.keys[j]
+}
+
+func (s *sorter) Swap(i, j int) {
+	s.keys[i], s.keys[j] = s.keys[j], s.keys[i]
+	s.numbers[i], s.numbers[j] = s.numbers[j], s.numbers[i]
+	s.swap(i, j)
+}
@@ -0,0 +1,67 @@
+package console
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSort(t *testing.T) {
+	tests := []struct {
+		column   string
+		reverse  bool
+		expected []int
+	}{
+		{column: "id", expected: []int{1, 2, 10}},
+		{column: "id", reverse: true, expected: []int{10, 2, 1}},
+		{column: "name", expected: []int{10, 1, 2}},
+		{column: "public-ip", expected: []int{1, 10, 2}},
+	}
+
+	for _, tt := range tests {
+		name := tt.column
+		if tt.reverse {
+			name += " reverse"
+		}
+
+		t.Run(name, func(t *testing.T) {
+			rows := make([]testRow, len(testRows))
+			copy(rows, testRows)
+
+			if err := Sort(rows, tt.column, tt.reverse); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			ids := make([]int, len(rows))
+			for i, row := range rows {
+				ids[i] = row.ID
+			}
+
+			if !reflect.DeepEqual(ids, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, ids)
+			}
+		})
+	}
+}
+
+func TestSort_UnknownColumn(t *testing.T) {
+	tests := []struct {
+		name  string
+		items interface{}
+	}{
+		{name: "list", items: append([]testRow{}, testRows...)},
+		{name: "empty list", items: []testRow{}},
+		{name: "empty pointer list", items: []*testRow{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := Sort(tt.items, "status", false); err == nil {
+				t.Error("expected an error for an unknown column")
+			}
+		})
+	}
+
+	if err := Sort([]interface{}{}, "status", false); err != nil {
+		t.Errorf("unexpected error for an empty list of interfaces: %v", err)
+	}
+}
@@ -1,7 +1,10 @@
 package console
 
 import (
+	"encoding/csv"
 	"fmt"
+	"io"
+	"path"
 	"reflect"
 	"strings"
 )
@@ -11,19 +14,33 @@ type Displayable interface {
 	Values() map[string]interface{}
 }
 
+// CompactDisplayable is implemented by types which only display a subset of
+// their columns by default. All columns are displayed in wide mode.
+type CompactDisplayable interface {
+	Displayable
+	CompactColumns() []string
+}
+
 type Column struct {
 	Index int
 	Name  string
 	Width int
 }
 
+// minColumnWidth is the minimum width to which columns are shrunk to fit the
+// table into MaxWidth.
+const minColumnWidth = 8
+
 type Table struct {
-	Columns []*Column
-	Rows    [][]string
-}
+	Columns     []*Column
+	Rows        [][]string
+	HideHeaders bool
 
-func (c *Column) format() string {
-	return fmt.Sprintf("%%%ds", -c.Width)
+	// MaxWidth limits the width of pretty tables in terminal cells by
+	// truncating the widest columns. Zero disables the limit.
+	MaxWidth int
+
+	displayable Displayable
 }
 
 func (t *Table) FindColumn(name string) *Column {
@@ -35,12 +52,120 @@ func (t *Table) FindColumn(name string) *Column {
 	return nil
 }
 
+// Kind returns the name of the displayed type including its package, for
+// example "compute.server". It is empty if no Displayable has been inserted.
+func (t *Table) Kind() string {
+	if t.displayable == nil {
+		return ""
+	}
+
+	typ := reflect.TypeOf(t.displayable)
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	pkg := path.Base(typ.PkgPath())
+	return strings.ToLower(pkg + "." + typ.Name())
+}
+
+// CompactColumns returns the columns which should be displayed by default.
+func (t *Table) CompactColumns() []string {
+	if compact, ok := t.displayable.(CompactDisplayable); ok {
+		return compact.CompactColumns()
+	}
+
+	return t.columnNames()
+}
+
+// SelectColumns reduces the table to the given columns in the given order.
+// Column names are matched case-insensitively and dashes or underscores may
+// be used in place of spaces.
+func (t *Table) SelectColumns(names []string) error {
+	if len(t.Columns) == 0 {
+		// an empty list has no columns, but the selection is validated anyway
+		if t.displayable != nil {
+			for _, name := range names {
+				if len(findColumnName(t.displayable.Columns(), name)) == 0 {
+					return unknownColumnError(name, t.displayable.Columns())
+				}
+			}
+		}
+
+		return nil
+	}
+
+	selected := make([]*Column, len(names))
+	for idx, name := range names {
+		col := t.findColumnByAlias(name)
+		if col == nil {
+			return unknownColumnError(name, t.columnNames())
+		}
+
+		selected[idx] = col
+	}
+
+	rows := make([][]string, len(t.Rows))
+	for i, row := range t.Rows {
+		rows[i] = make([]string, len(selected))
+		for j, col := range selected {
+			rows[i][j] = row[col.Index]
+		}
+	}
+
+	columns := make([]*Column, len(selected))
+	for idx, col := range selected {
+		columns[idx] = &Column{
+			Index: idx,
+			Name:  col.Name,
+			Width: col.Width,
+		}
+	}
+
+	t.Columns = columns
+	t.Rows = rows
+	return nil
+}
+
+func (t *Table) findColumnByAlias(name string) *Column {
+	name = normalizeColumnName(name)
+
+	for _, col := range t.Columns {
+		if normalizeColumnName(col.Name) == name {
+			return col
+		}
+	}
+
+	return nil
+}
+
+// findColumnName returns the column matching the name or alias, or an empty
+// string if there is none.
+func findColumnName(columns []string, name string) string {
+	for _, col := range columns {
+		if normalizeColumnName(col) == normalizeColumnName(name) {
+			return col
+		}
+	}
+
+	return ""
+}
+
+func unknownColumnError(name string, available []string) error {
+	return fmt.Errorf("unknown column %q, available columns are: %s", name, strings.Join(available, ", "))
+}
+
+var columnNameReplacer = strings.NewReplacer("-", " ", "_", " ")
+
+func normalizeColumnName(name string) string {
+	return columnNameReplacer.Replace(strings.ToLower(strings.TrimSpace(name)))
+}
+
 func (t *Table) insertColumns(cols []string) {
 	for idx, col := range cols {
 		t.Columns = append(t.Columns, &Column{
 			Index: idx,
 			Name:  col,
-			Width: len(col),
+			Width: DisplayWidth(col),
 		})
 	}
 }
@@ -57,41 +182,32 @@ func (t *Table) insertRow(data map[string]interface{}) {
 		str := fmt.Sprintf("%+v", val)
 		row[col.Index] = str
 
-		if len(str) > col.Width {
-			col.Width = len(str)
+		if w := DisplayWidth(str); w > col.Width {
+			col.Width = w
 		}
 	}
 
 	t.Rows = append(t.Rows, row)
 }
 
-func (t *Table) Format(out Writer, separator string, pretty bool) {
-	format := "%s"
-	for idx, col := range t.Columns {
-		if pretty {
-			format = col.format()
-		}
+func (t *Table) Format(out Writer, separator string) {
+	widths := t.columnWidths(DisplayWidth(separator))
 
-		out.Bold().Printf(format, strings.ToUpper(col.Name)).Reset()
+	if !t.HideHeaders {
+		for idx, col := range t.Columns {
+			out.Bold().Print(t.cell(strings.ToUpper(col.Name), idx, widths)).Reset()
 
-		if (idx + 1) < len(t.Columns) {
-			out.Print(separator)
+			if (idx + 1) < len(t.Columns) {
+				out.Print(separator)
+			}
 		}
-	}
 
-	out.Println()
+		out.Println()
+	}
 
 	for _, row := range t.Rows {
 		for idx, val := range row {
-			if pretty {
-				format = t.Columns[idx].format()
-			}
-
-			if !pretty && strings.Contains(val, separator) {
-				val = fmt.Sprintf("\"%s\"", strings.ReplaceAll(val, "\"", "\"\""))
-			}
-
-			out.Printf(format, val)
+			out.Print(t.cell(val, idx, widths))
 
 			if (idx + 1) < len(row) {
 				out.Print(separator)
@@ -102,6 +218,106 @@ func (t *Table) Format(out Writer, separator string, pretty bool) {
 	}
 }
 
+// WriteCSV writes the table as RFC 4180 compliant csv without any colors.
+func (t *Table) WriteCSV(out io.Writer) error {
+	writer := csv.NewWriter(out)
+
+	if !t.HideHeaders {
+		if err := writer.Write(t.columnNames()); err != nil {
+			return err
+		}
+	}
+
+	if err := writer.WriteAll(t.Rows); err != nil {
+		return err
+	}
+
+	return writer.Error()
+}
+
+// WriteTSV writes the table as tab separated values. Tabs, line breaks and
+// backslashes within values are escaped as \t, \n, \r and \\.
+func (t *Table) WriteTSV(out io.Writer) error {
+	write := func(values []string) error {
+		escaped := make([]string, len(values))
+		for idx, val := range values {
+			escaped[idx] = tsvEscaper.Replace(val)
+		}
+
+		_, err := io.WriteString(out, strings.Join(escaped, "\t")+"\n")
+		return err
+	}
+
+	if !t.HideHeaders {
+		if err := write(t.columnNames()); err != nil {
+			return err
+		}
+	}
+
+	for _, row := range t.Rows {
+		if err := write(row); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+var tsvEscaper = strings.NewReplacer("\\", "\\\\", "\t", "\\t", "\n", "\\n", "\r", "\\r")
+
+func (t *Table) columnNames() []string {
+	names := make([]string, len(t.Columns))
+	for idx, col := range t.Columns {
+		names[idx] = col.Name
+	}
+	return names
+}
+
+func (t *Table) cell(val string, idx int, widths []int) string {
+	val = Truncate(val, widths[idx])
+
+	// do not pad the last column to avoid trailing whitespace
+	if (idx + 1) == len(t.Columns) {
+		return val
+	}
+
+	return Pad(val, widths[idx])
+}
+
+// columnWidths returns the width of each column, shrinking the widest columns
+// until the table fits into MaxWidth.
+func (t *Table) columnWidths(separatorWidth int) []int {
+	widths := make([]int, len(t.Columns))
+	total := separatorWidth * (len(t.Columns) - 1)
+
+	for idx, col := range t.Columns {
+		widths[idx] = col.Width
+		total += col.Width
+	}
+
+	if t.MaxWidth <= 0 {
+		return widths
+	}
+
+	for total > t.MaxWidth {
+		widest := 0
+		for idx := range widths {
+			if widths[idx] > widths[widest] {
+				widest = idx
+			}
+		}
+
+		if widths[widest] <= minColumnWidth {
+			break
+		}
+
+		widths[widest]--
+		total--
+	}
+
+	return widths
+}
+
 func (t *Table) insertMap(value reflect.Value) error {
 	if t.Columns == nil {
 		var cols []string
@@ -130,6 +346,7 @@ func (t *Table) insertStruct(value reflect.Value) error {
 	displayable := value.Interface().(Displayable)
 
 	if t.Columns == nil {
+		t.displayable = displayable
 		t.insertColumns(displayable.Columns())
 	}
 
@@ -143,6 +360,11 @@ func (t *Table) insertValue(value reflect.Value) error {
 	case reflect.Array:
 		fallthrough
 	case reflect.Slice:
+		if value.Len() == 0 && t.displayable == nil {
+			// remember the displayed type to validate the columns of empty lists
+			t.displayable = elementDisplayable(value.Type())
+		}
+
 		for i := 0; i < value.Len(); i++ {
 			err := t.insertValue(value.Index(i))
 			if err != nil {
@@ -161,6 +383,21 @@ func (t *Table) insertValue(value reflect.Value) error {
 	return fmt.Errorf("unable to serialize value of type %q (%q)", value.Type().String(), value.Kind().String())
 }
 
+// elementDisplayable returns a zero value of the element type of the slice or
+// array type, which provides the columns of empty lists. It returns nil if the
+// elements are not Displayable.
+func elementDisplayable(typ reflect.Type) Displayable {
+	elem := typ.Elem()
+
+	value := reflect.Zero(elem)
+	if elem.Kind() == reflect.Ptr {
+		value = reflect.New(elem.Elem())
+	}
+
+	displayable, _ := value.Interface().(Displayable)
+	return displayable
+}
+
 func (t *Table) Insert(val interface{}) error {
 	return t.insertValue(reflect.ValueOf(val))
 }
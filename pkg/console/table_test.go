@@ -0,0 +1,72 @@
+package console
+
+import (
+	"reflect"
+	"testing"
+)
+
+type testRow struct {
+	ID       int
+	Name     string
+	PublicIP string
+}
+
+func (r testRow) Columns() []string {
+	return []string{"id", "name", "public ip"}
+}
+
+func (r testRow) Values() map[string]interface{} {
+	return map[string]interface{}{
+		"id":        r.ID,
+		"name":      r.Name,
+		"public ip": r.PublicIP,
+	}
+}
+
+var testRows = []testRow{
+	{ID: 2, Name: "web-2", PublicIP: "192.0.2.2"},
+	{ID: 10, Name: "db-1", PublicIP: "192.0.2.10"},
+	{ID: 1, Name: "web-1", PublicIP: ""},
+}
+
+func TestTable_SelectColumns(t *testing.T) {
+	tests := []struct {
+		name     string
+		items    interface{}
+		columns  []string
+		expected []string
+		err      bool
+	}{
+		{name: "columns", items: testRows, columns: []string{"name", "id"}, expected: []string{"name", "id"}},
+		{name: "alias", items: testRows, columns: []string{"public-ip"}, expected: []string{"public ip"}},
+		{name: "unknown column", items: testRows, columns: []string{"status"}, err: true},
+		{name: "empty list", items: []testRow{}, columns: []string{"public-ip"}, expected: []string{}},
+		{name: "unknown column of empty list", items: []testRow{}, columns: []string{"status"}, err: true},
+		{name: "unknown column of empty pointer list", items: []*testRow{}, columns: []string{"status"}, err: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table := Table{}
+			if err := table.Insert(tt.items); err != nil {
+				t.Fatal(err)
+			}
+
+			err := table.SelectColumns(tt.columns)
+			if tt.err {
+				if err == nil {
+					t.Errorf("expected an error, got columns %v", table.columnNames())
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if names := table.columnNames(); !reflect.DeepEqual(names, tt.expected) {
+				t.Errorf("expected columns %v, got %v", tt.expected, names)
+			}
+		})
+	}
+}
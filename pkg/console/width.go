@@ -0,0 +1,90 @@
+package console
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/term"
+	"golang.org/x/text/width"
+)
+
+const ellipsis = "…"
+
+// DisplayWidth returns the number of terminal cells needed to display the
+// string. East asian wide characters take up two cells, while combining marks
+// and control characters do not take up any space.
+func DisplayWidth(str string) int {
+	total := 0
+	for _, r := range str {
+		total += runeWidth(r)
+	}
+	return total
+}
+
+func runeWidth(r rune) int {
+	if unicode.IsControl(r) || unicode.In(r, unicode.Mn, unicode.Me, unicode.Cf) {
+		return 0
+	}
+
+	switch width.LookupRune(r).Kind() {
+	case width.EastAsianWide, width.EastAsianFullwidth:
+		return 2
+	}
+
+	return 1
+}
+
+// Truncate shortens the string to fit into the given number of terminal
+// cells, marking the truncation with an ellipsis.
+func Truncate(str string, cells int) string {
+	if DisplayWidth(str) <= cells {
+		return str
+	}
+
+	if cells <= 0 {
+		return ""
+	}
+
+	buf := &strings.Builder{}
+	used := 0
+
+	for _, r := range str {
+		w := runeWidth(r)
+		if used+w > cells-1 {
+			break
+		}
+
+		buf.WriteRune(r)
+		used += w
+	}
+
+	buf.WriteString(ellipsis)
+	return buf.String()
+}
+
+// Pad appends spaces to the string until it fills the given number of
+// terminal cells.
+func Pad(str string, cells int) string {
+	missing := cells - DisplayWidth(str)
+	if missing <= 0 {
+		return str
+	}
+
+	return str + strings.Repeat(" ", missing)
+}
+
+// TerminalWidth returns the width of the terminal the writer is connected to
+// or zero if it is not a terminal.
+func TerminalWidth(out Writer) int {
+	file, ok := out.(interface{ Fd() uintptr })
+	if !ok {
+		return 0
+	}
+
+	cols, _, err := term.GetSize(int(file.Fd()))
+	if err != nil {
+		return 0
+	}
+
+	return cols
+}
@@ -0,0 +1,214 @@
+package filter
+
+import (
+	"fmt"
+	"strings"
+	"text/tabwriter"
+)
+
+const (
+	maxCandidates  = 10
+	maxSuggestions = 3
+)
+
+type candidate struct {
+	id   string
+	name string
+}
+
+func newCandidate(item Filterable) candidate {
+	var c candidate
+
+	if valuable, ok := item.(Valuable); ok {
+		values := valuable.Values()
+		if id, ok := values["id"]; ok {
+			c.id = fmt.Sprint(id)
+		}
+
+		if name, ok := values["name"]; ok {
+			c.name = fmt.Sprint(name)
+		}
+	}
+
+	keys := item.Keys()
+	if len(c.id) == 0 && len(keys) > 0 {
+		c.id = keys[0]
+	}
+
+	if len(c.name) == 0 && len(keys) > 1 {
+		c.name = keys[1]
+	}
+
+	return c
+}
+
+type ambiguousError struct {
+	term       string
+	candidates []candidate
+}
+
+func newAmbiguousError[T Filterable](term string, items []T) ambiguousError {
+	err := ambiguousError{term: term}
+	for _, item := range items {
+		err.candidates = append(err.candidates, newCandidate(item))
+	}
+
+	return err
+}
+
+func (a ambiguousError) Error() string {
+	builder := &strings.Builder{}
+	fmt.Fprintf(builder, "term %q is ambiguous, it matches:\n", a.term)
+
+	out := tabwriter.NewWriter(builder, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(out, "  ID\tNAME")
+
+	for idx, c := range a.candidates {
+		if idx == maxCandidates {
+			fmt.Fprintf(out, "  ...\tand %d more\n", len(a.candidates)-maxCandidates)
+			break
+		}
+
+		fmt.Fprintf(out, "  %s\t%s\n", c.id, c.name)
+	}
+
+	_ = out.Flush()
+	return strings.TrimSuffix(builder.String(), "\n")
+}
+
+// descriptions returns the id and name of every candidate aligned in columns.
+func (a ambiguousError) descriptions() []string {
+	builder := &strings.Builder{}
+
+	out := tabwriter.NewWriter(builder, 0, 0, 3, ' ', 0)
+	for _, c := range a.candidates {
+		fmt.Fprintf(out, "%s\t%s\n", c.id, c.name)
+	}
+
+	_ = out.Flush()
+	return strings.Split(strings.TrimSuffix(builder.String(), "\n"), "\n")
+}
+
+type notFoundError struct {
+	term        string
+	suggestions []string
+}
+
+func newNotFoundError[T Filterable](term string, items []T) notFoundError {
+	return notFoundError{term: term, suggestions: suggest(term, items)}
+}
+
+func (n notFoundError) Error() string {
+	msg := fmt.Sprintf("no item found searching for the term %q", n.term)
+	if len(n.suggestions) == 0 {
+		return msg
+	}
+
+	quoted := make([]string, len(n.suggestions))
+	for idx, suggestion := range n.suggestions {
+		quoted[idx] = fmt.Sprintf("%q", suggestion)
+	}
+
+	return fmt.Sprintf("%s, did you mean %s?", msg, strings.Join(quoted, " or "))
+}
+
+// suggest returns the keys of the items closest to the term. Every key of an
+// item is compared and only the closest one is suggested. Keys within an edit
+// distance of a third of the term length (at least 2) are considered.
+func suggest[T Filterable](term string, items []T) []string {
+	term = strings.ToLower(term)
+
+	threshold := len([]rune(term)) / 3
+	if threshold < 2 {
+		threshold = 2
+	}
+
+	type suggestion struct {
+		key      string
+		distance int
+	}
+
+	var suggestions []suggestion
+	seen := map[string]bool{}
+
+	for _, item := range items {
+		best := suggestion{distance: -1}
+		for _, key := range item.Keys() {
+			if len(key) == 0 {
+				continue
+			}
+
+			distance := editDistance(term, strings.ToLower(key))
+			if best.distance < 0 || distance < best.distance {
+				best = suggestion{key: key, distance: distance}
+			}
+		}
+
+		if best.distance < 0 || best.distance > threshold || seen[best.key] {
+			continue
+		}
+
+		seen[best.key] = true
+
+		// insert the suggestion sorted by distance, keeping the original order for equal distances
+		idx := len(suggestions)
+		for idx > 0 && suggestions[idx-1].distance > best.distance {
+			idx--
+		}
+
+		suggestions = append(suggestions, suggestion{})
+		copy(suggestions[idx+1:], suggestions[idx:])
+		suggestions[idx] = best
+	}
+
+	if len(suggestions) > maxSuggestions {
+		suggestions = suggestions[:maxSuggestions]
+	}
+
+	res := make([]string, len(suggestions))
+	for idx, s := range suggestions {
+		res[idx] = s.key
+	}
+
+	return res
+}
+
+// editDistance returns the levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	source, target := []rune(a), []rune(b)
+
+	previous := make([]int, len(target)+1)
+	current := make([]int, len(target)+1)
+
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(source); i++ {
+		current[0] = i
+
+		for j := 1; j <= len(target); j++ {
+			cost := 1
+			if source[i-1] == target[j-1] {
+				cost = 0
+			}
+
+			current[j] = minInt(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+
+		previous, current = current, previous
+	}
+
+	return previous[len(target)]
+}
+
+func minInt(values ...int) int {
+	res := values[0]
+	for _, value := range values[1:] {
+		if value < res {
+			res = value
+		}
+	}
+
+	return res
+}
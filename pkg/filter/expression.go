@@ -0,0 +1,186 @@
+package filter
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Valuable is implemented by items which expose named fields, like the
+// columns of a table. Filter expressions are evaluated against these fields.
+type Valuable interface {
+	Values() map[string]interface{}
+}
+
+type operator int
+
+const (
+	operatorEqual operator = iota
+	operatorNotEqual
+	operatorMatch
+	operatorNotMatch
+)
+
+type condition struct {
+	field    string
+	operator operator
+	value    string
+	regex    *regexp.Regexp
+}
+
+type expression []condition
+
+var fieldRegex = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9 _-]*$`)
+
+var fieldReplacer = strings.NewReplacer("-", " ", "_", " ")
+
+// parseExpression parses a comma separated list of conditions in the form
+// `field=value`, `field!=value`, `field~regex` or `field!~regex`. All
+// conditions must be satisfied for an item to match. If the term is not a
+// valid expression, false is returned and the term is treated as a bare term.
+// The regular expressions are compiled separately, see compile.
+func parseExpression(term string) (expression, bool) {
+	if !strings.ContainsAny(term, "=~") {
+		return nil, false
+	}
+
+	var expr expression
+	for _, part := range strings.Split(term, ",") {
+		cond, ok := parseCondition(part)
+		if !ok {
+			return nil, false
+		}
+
+		expr = append(expr, cond)
+	}
+
+	return expr, true
+}
+
+func parseCondition(text string) (condition, bool) {
+	idx := strings.IndexAny(text, "=~")
+	if idx < 0 {
+		return condition{}, false
+	}
+
+	cond := condition{value: strings.TrimSpace(text[idx+1:])}
+	field := text[:idx]
+	negated := strings.HasSuffix(field, "!")
+	if negated {
+		field = field[:len(field)-1]
+	}
+
+	field = strings.TrimSpace(field)
+	if !fieldRegex.MatchString(field) {
+		return condition{}, false
+	}
+
+	cond.field = normalizeField(field)
+
+	switch {
+	case text[idx] == '=' && negated:
+		cond.operator = operatorNotEqual
+	case text[idx] == '=':
+		cond.operator = operatorEqual
+	case negated:
+		cond.operator = operatorNotMatch
+	default:
+		cond.operator = operatorMatch
+	}
+
+	return cond, true
+}
+
+// compile compiles the regular expressions of all match conditions. An error
+// is returned if one of them is invalid.
+func (e expression) compile() error {
+	for idx := range e {
+		cond := &e[idx]
+		if cond.operator != operatorMatch && cond.operator != operatorNotMatch {
+			continue
+		}
+
+		regex, err := regexp.Compile("(?i)" + cond.value)
+		if err != nil {
+			return fmt.Errorf("invalid regular expression %q for field %q: %w", cond.value, cond.field, err)
+		}
+
+		cond.regex = regex
+	}
+
+	return nil
+}
+
+// validate ensures that all fields of the expression exist in the sample item,
+// so that typos do not silently result in no matches. A nil sample is always
+// valid, as there is nothing to compare with.
+func (e expression) validate(sample interface{}) error {
+	if sample == nil {
+		return nil
+	}
+
+	valuable, ok := sample.(Valuable)
+	if !ok {
+		return fmt.Errorf("filter expressions are not supported for these items")
+	}
+
+	fields := map[string]bool{}
+	var available []string
+	for key := range valuable.Values() {
+		fields[normalizeField(key)] = true
+		available = append(available, key)
+	}
+
+	for _, cond := range e {
+		if !fields[cond.field] {
+			sort.Strings(available)
+			return fmt.Errorf("unknown field %q in filter expression, available fields: %s", cond.field, strings.Join(available, ", "))
+		}
+	}
+
+	return nil
+}
+
+func (e expression) matches(item interface{}) bool {
+	valuable, ok := item.(Valuable)
+	if !ok {
+		return false
+	}
+
+	values := valuable.Values()
+	for _, cond := range e {
+		if !cond.matches(values) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (c condition) matches(values map[string]interface{}) bool {
+	for key, raw := range values {
+		if normalizeField(key) != c.field {
+			continue
+		}
+
+		value := fmt.Sprintf("%+v", raw)
+
+		switch c.operator {
+		case operatorEqual:
+			return strings.EqualFold(value, c.value)
+		case operatorNotEqual:
+			return !strings.EqualFold(value, c.value)
+		case operatorMatch:
+			return c.regex.MatchString(value)
+		case operatorNotMatch:
+			return !c.regex.MatchString(value)
+		}
+	}
+
+	return false
+}
+
+func normalizeField(name string) string {
+	return fieldReplacer.Replace(strings.ToLower(strings.TrimSpace(name)))
+}
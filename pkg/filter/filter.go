@@ -2,44 +2,66 @@ package filter
 
 import (
 	"fmt"
+	"path"
+	"regexp"
 	"strings"
 )
 
+const (
+	PrefixRegex = "re:"
+	PrefixGlob  = "glob:"
+)
+
+// Exact disables partial matches in FindOne, so that a term only resolves to
+// an item whose key is exactly equal to the term. Regex and glob patterns as
+// well as filter expressions are still evaluated.
+var Exact bool
+
+// Select is called by FindOne if a term matches multiple items. It receives
+// the term and a description of each candidate and returns the index of the
+// selected candidate or -1 if none was selected. If Select is nil, ambiguous
+// terms result in an error.
+var Select func(term string, candidates []string) (int, error)
+
 type Filterable interface {
 	Keys() []string
 }
 
-func Find[T Filterable](items []T, term string) []T {
-	term = strings.ToLower(term)
+// Find returns all items matching the term. The term is either a bare term,
+// which is matched case-insensitively against the keys of the items, a
+// regular expression prefixed with `re:` or a glob pattern prefixed with
+// `glob:` which are matched against the keys as well, or a filter expression
+// like `status=running,name~^web-` which is evaluated against the values of the
+// items. An expression referring to fields the items do not have is matched
+// as a bare term instead. An error is returned if the pattern or expression is
+// invalid or if such an expression does not match any item as a bare term.
+func Find[T Filterable](items []T, term string) ([]T, error) {
+	return FindWithCustomFilter(items, term, nil)
+}
 
-	var filtered []T
-	for _, item := range items {
-		if matches(item, term) != noMatch {
-			filtered = append(filtered, item)
-		}
+func FindWithCustomFilter[T Filterable](items []T, term string, filter func(T) bool) ([]T, error) {
+	match, err := newMatcher(term, items)
+	if err != nil {
+		return nil, err
 	}
 
-	return filtered
+	return find(items, match, filter), nil
 }
 
-func FindWithCustomFilter[T Filterable](items []T, term string, filter func(T) bool) []T {
-	term = strings.ToLower(term)
-
-	var filtered []T
-	for _, item := range items {
-		if matches(item, term) != noMatch && (filter == nil || filter(item)) {
-			filtered = append(filtered, item)
-		}
+func FindOne[T Filterable](items []T, term string) (res T, err error) {
+	match, err := newMatcher(term, items)
+	if err != nil {
+		return res, err
 	}
 
-	return filtered
-}
+	if Exact {
+		match = withoutPartialMatches(match)
+	}
 
-func FindOne[T Filterable](items []T, term string) (res T, err error) {
-	var filtered = Find[T](items, term)
+	filtered := find(items, match, nil)
 
 	if len(filtered) == 0 {
-		return res, fmt.Errorf("no item found searching for the term %q", term)
+		return res, newNotFoundError(term, items)
 	}
 
 	if len(filtered) > 1 {
@@ -48,19 +70,19 @@ func FindOne[T Filterable](items []T, term string) (res T, err error) {
 
 		// find the best match (the one that matches exactly the term)
 		for _, item := range filtered {
-			if matches(item, term) == exactMatch {
+			if match(item) == exactMatch {
 				if ambiguous {
 					bestMatch = item
 					ambiguous = false
 				} else {
-					// multiple matches found, return an error
-					return res, ambiguousError(term)
+					// multiple matches found, let the user select one
+					return selectOne(term, filtered)
 				}
 			}
 		}
 
 		if ambiguous {
-			return res, ambiguousError(term)
+			return selectOne(term, filtered)
 		}
 
 		return bestMatch, nil
@@ -69,10 +91,18 @@ func FindOne[T Filterable](items []T, term string) (res T, err error) {
 	return filtered[0], nil
 }
 
-type ambiguousError string
+func selectOne[T Filterable](term string, items []T) (res T, err error) {
+	ambiguous := newAmbiguousError(term, items)
+	if Select == nil {
+		return res, ambiguous
+	}
+
+	idx, err := Select(term, ambiguous.descriptions())
+	if err != nil || idx < 0 || idx >= len(items) {
+		return res, ambiguous
+	}
 
-func (a ambiguousError) Error() string {
-	return fmt.Sprintf("term %q is ambiguous", string(a))
+	return items[idx], nil
 }
 
 type matchType int
@@ -81,9 +111,116 @@ const (
 	noMatch matchType = iota
 	exactMatch
 	partialMatch
+	patternMatch
 )
 
-func matches[T Filterable](item T, term string) matchType {
+type matcher func(item interface{}) matchType
+
+func find[T Filterable](items []T, match matcher, filter func(T) bool) []T {
+	var filtered []T
+	for _, item := range items {
+		if match(item) != noMatch && (filter == nil || filter(item)) {
+			filtered = append(filtered, item)
+		}
+	}
+
+	return filtered
+}
+
+// sample returns the first item, which is used to validate the fields of
+// filter expressions, or nil if there are no items.
+func sample[T Filterable](items []T) interface{} {
+	if len(items) == 0 {
+		return nil
+	}
+
+	return items[0]
+}
+
+func newMatcher[T Filterable](term string, items []T) (matcher, error) {
+	switch {
+	case strings.HasPrefix(term, PrefixRegex):
+		regex, err := regexp.Compile("(?i)" + strings.TrimPrefix(term, PrefixRegex))
+		if err != nil {
+			return nil, fmt.Errorf("invalid regular expression %q: %w", term, err)
+		}
+
+		return matchKeys(regex.MatchString), nil
+
+	case strings.HasPrefix(term, PrefixGlob):
+		pattern := strings.ToLower(strings.TrimPrefix(term, PrefixGlob))
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid glob pattern %q: %w", term, err)
+		}
+
+		return matchKeys(func(key string) bool {
+			matched, _ := path.Match(pattern, strings.ToLower(key))
+			return matched
+		}), nil
+	}
+
+	expr, ok := parseExpression(term)
+	if !ok {
+		return matchTerm(term), nil
+	}
+
+	if err := expr.validate(sample(items)); err != nil {
+		// names may contain "=" or "~" as well, so a term referring to fields the
+		// items do not have is matched as a bare term. The unknown field is only
+		// reported if the bare term does not match any item either.
+		match := matchTerm(term)
+		if len(find(items, match, nil)) == 0 {
+			return nil, err
+		}
+
+		return match, nil
+	}
+
+	if err := expr.compile(); err != nil {
+		return nil, err
+	}
+
+	return func(item interface{}) matchType {
+		if expr.matches(item) {
+			return patternMatch
+		}
+
+		return noMatch
+	}, nil
+}
+
+func matchTerm(term string) matcher {
+	term = strings.ToLower(term)
+	return func(item interface{}) matchType {
+		return matches(item.(Filterable), term)
+	}
+}
+
+func withoutPartialMatches(match matcher) matcher {
+	return func(item interface{}) matchType {
+		if res := match(item); res != partialMatch {
+			return res
+		}
+
+		return noMatch
+	}
+}
+
+func matchKeys(match func(key string) bool) matcher {
+	return func(item interface{}) matchType {
+		for _, key := range item.(Filterable).Keys() {
+			if match(key) {
+				return patternMatch
+			}
+		}
+
+		return noMatch
+	}
+}
+
+func matches(item Filterable, term string) matchType {
+	res := noMatch
+
 	identifiers := item.Keys()
 	for _, identifier := range identifiers {
 		identifier = strings.ToLower(identifier)
@@ -93,9 +230,9 @@ func matches[T Filterable](item T, term string) matchType {
 		}
 
 		if strings.Contains(identifier, term) {
-			return partialMatch
+			res = partialMatch
 		}
 	}
 
-	return noMatch
+	return res
 }
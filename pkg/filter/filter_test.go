@@ -0,0 +1,203 @@
+package filter
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+type testItem struct {
+	id     int
+	name   string
+	status string
+}
+
+func (t testItem) Keys() []string {
+	return []string{fmt.Sprint(t.id), t.name}
+}
+
+func (t testItem) Values() map[string]interface{} {
+	return map[string]interface{}{
+		"id":     t.id,
+		"name":   t.name,
+		"status": t.status,
+	}
+}
+
+var testItems = []testItem{
+	{id: 1, name: "web-1", status: "running"},
+	{id: 2, name: "web-2", status: "stopped"},
+	{id: 3, name: "db-1", status: "running"},
+}
+
+func TestFind(t *testing.T) {
+	tests := []struct {
+		term     string
+		expected []int
+	}{
+		{term: "web", expected: []int{1, 2}},
+		{term: "re:^web-\\d$", expected: []int{1, 2}},
+		{term: "glob:*-1", expected: []int{1, 3}},
+		{term: "status=running", expected: []int{1, 3}},
+		{term: "status=running,name~^web", expected: []int{1}},
+		{term: "status!=running", expected: []int{2}},
+		{term: "name!~web", expected: []int{3}},
+		{term: "unknown", expected: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.term, func(t *testing.T) {
+			items, err := Find(testItems, tt.term)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var ids []int
+			for _, item := range items {
+				ids = append(ids, item.id)
+			}
+
+			if !reflect.DeepEqual(ids, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, ids)
+			}
+		})
+	}
+}
+
+func TestFind_InvalidPattern(t *testing.T) {
+	terms := []string{"re:[", "glob:[", "name~["}
+
+	for _, term := range terms {
+		t.Run(term, func(t *testing.T) {
+			if items, err := Find(testItems, term); err == nil {
+				t.Errorf("expected an error, got %v", items)
+			}
+
+			if _, err := FindOne(testItems, term); err == nil {
+				t.Errorf("expected an error from FindOne")
+			}
+		})
+	}
+}
+
+func TestFind_UnknownField(t *testing.T) {
+	_, err := Find(testItems, "stauts=running")
+	if err == nil {
+		t.Fatal("expected an error for an unknown field")
+	}
+
+	expected := `unknown field "stauts" in filter expression, available fields: id, name, status`
+	if err.Error() != expected {
+		t.Errorf("expected error %q, got %q", expected, err)
+	}
+
+	// without items there is nothing to validate the fields against
+	if _, err := Find([]testItem{}, "stauts=running"); err != nil {
+		t.Errorf("unexpected error for empty items: %v", err)
+	}
+
+	if _, err := FindOne(testItems, "stauts=running"); err == nil {
+		t.Error("expected an error from FindOne for an unknown field")
+	}
+}
+
+func TestFind_TermWithOperator(t *testing.T) {
+	items := append([]testItem{
+		{id: 4, name: "web=1", status: "running"},
+		{id: 5, name: "db~2", status: "stopped"},
+	}, testItems...)
+
+	tests := []struct {
+		term     string
+		expected []int
+	}{
+		{term: "web=1", expected: []int{4}},
+		{term: "db~2", expected: []int{5}},
+		{term: "status=stopped", expected: []int{5, 2}},
+		{term: "name~[", expected: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.term, func(t *testing.T) {
+			filtered, err := Find(items, tt.term)
+			if tt.expected == nil {
+				if err == nil {
+					t.Errorf("expected an error, got %v", filtered)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var ids []int
+			for _, item := range filtered {
+				ids = append(ids, item.id)
+			}
+
+			if !reflect.DeepEqual(ids, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, ids)
+			}
+		})
+	}
+
+	item, err := FindOne(items, "web=1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if item.id != 4 {
+		t.Errorf("expected item 4, got %d", item.id)
+	}
+}
+
+type testImage struct {
+	id      int
+	key     string
+	os      string
+	version string
+}
+
+func (t testImage) Keys() []string {
+	return []string{fmt.Sprint(t.id), t.key, t.os + " " + t.version}
+}
+
+func TestFindOne_Suggestions(t *testing.T) {
+	images := []testImage{
+		{id: 1, key: "linux-ubuntu-22.04-lts", os: "Ubuntu", version: "22.04"},
+		{id: 2, key: "linux-ubuntu-20.04-lts", os: "Ubuntu", version: "20.04"},
+		{id: 3, key: "linux-debian-11", os: "Debian", version: "11"},
+	}
+
+	tests := []struct {
+		term     string
+		expected string
+	}{
+		{
+			term:     "ubunt-22.04",
+			expected: `no item found searching for the term "ubunt-22.04", did you mean "Ubuntu 22.04" or "Ubuntu 20.04"?`,
+		},
+		{
+			term:     "linux-debain-11",
+			expected: `no item found searching for the term "linux-debain-11", did you mean "linux-debian-11"?`,
+		},
+		{
+			term:     "windows",
+			expected: `no item found searching for the term "windows"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.term, func(t *testing.T) {
+			_, err := FindOne(images, tt.term)
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+
+			if err.Error() != tt.expected {
+				t.Errorf("expected error %q, got %q", tt.expected, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,520 @@
+package jsonpath
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Template is a parsed jsonpath template in the style of kubectl, for example
+// `{.items[*].name}` or `{range .items[*]}{.id}{"\t"}{.name}{"\n"}{end}`.
+//
+// Supported are child fields (`.name` or `['name']`), wildcards (`*`),
+// indices (`[0]`, `[-1]`), slices (`[1:3]`), recursive descent (`..name`),
+// string literals and range blocks. Filter expressions are not supported.
+//
+// Like kubectl, a field missing in any of the evaluated objects results in an
+// error unless missing keys are allowed. Recursive descent only selects the
+// objects containing the field and never fails.
+type Template struct {
+	nodes            []node
+	allowMissingKeys bool
+}
+
+type node interface{}
+
+type textNode string
+
+type pathNode []step
+
+type rangeNode struct {
+	path  pathNode
+	nodes []node
+}
+
+// Parse parses the given jsonpath template.
+func Parse(text string) (*Template, error) {
+	root := &rangeNode{}
+	stack := []*rangeNode{root}
+
+	for len(text) != 0 {
+		start := strings.IndexByte(text, '{')
+		if start < 0 {
+			start = len(text)
+		}
+
+		current := stack[len(stack)-1]
+		if start != 0 {
+			current.nodes = append(current.nodes, textNode(text[:start]))
+			text = text[start:]
+			continue
+		}
+
+		end, err := findClosingBrace(text)
+		if err != nil {
+			return nil, err
+		}
+
+		expr := strings.TrimSpace(text[1:end])
+		text = text[end+1:]
+
+		switch {
+		case expr == "end":
+			if len(stack) == 1 {
+				return nil, fmt.Errorf("unexpected {end} without {range}")
+			}
+
+			stack = stack[:len(stack)-1]
+
+		case strings.HasPrefix(expr, "range ") || expr == "range":
+			path, err := parsePath(strings.TrimSpace(strings.TrimPrefix(expr, "range")))
+			if err != nil {
+				return nil, err
+			}
+
+			block := &rangeNode{path: path}
+			current.nodes = append(current.nodes, block)
+			stack = append(stack, block)
+
+		case strings.HasPrefix(expr, "\"") || strings.HasPrefix(expr, "'"):
+			literal, err := unquote(expr)
+			if err != nil {
+				return nil, fmt.Errorf("invalid string literal %s: %w", expr, err)
+			}
+
+			current.nodes = append(current.nodes, textNode(literal))
+
+		default:
+			path, err := parsePath(expr)
+			if err != nil {
+				return nil, err
+			}
+
+			current.nodes = append(current.nodes, path)
+		}
+	}
+
+	if len(stack) != 1 {
+		return nil, fmt.Errorf("missing {end} for {range}")
+	}
+
+	return &Template{nodes: root.nodes}, nil
+}
+
+// AllowMissingKeys controls whether missing fields are skipped instead of
+// resulting in an error.
+func (t *Template) AllowMissingKeys(allow bool) *Template {
+	t.allowMissingKeys = allow
+	return t
+}
+
+// Execute evaluates the template against data and writes the result to out.
+// The data is expected to consist of the types produced by decoding json into
+// an interface{}.
+func (t *Template) Execute(out io.Writer, data interface{}) error {
+	return t.execute(out, t.nodes, data, data)
+}
+
+func (t *Template) execute(out io.Writer, nodes []node, root, current interface{}) error {
+	for _, n := range nodes {
+		switch n := n.(type) {
+		case textNode:
+			if _, err := io.WriteString(out, string(n)); err != nil {
+				return err
+			}
+
+		case pathNode:
+			values, err := n.evaluate(root, current, t.allowMissingKeys)
+			if err != nil {
+				return err
+			}
+
+			for idx, value := range values {
+				if idx != 0 {
+					if _, err := io.WriteString(out, " "); err != nil {
+						return err
+					}
+				}
+
+				if _, err := io.WriteString(out, format(value)); err != nil {
+					return err
+				}
+			}
+
+		case *rangeNode:
+			values, err := n.path.evaluate(root, current, t.allowMissingKeys)
+			if err != nil {
+				return err
+			}
+
+			for _, value := range values {
+				if err := t.execute(out, n.nodes, root, value); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func format(value interface{}) string {
+	switch value := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return value
+	case json.Number:
+		return value.String()
+	case bool, float64, int, int64:
+		return fmt.Sprint(value)
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+
+	return string(data)
+}
+
+func findClosingBrace(text string) (int, error) {
+	end := findClosing(text, '}')
+	if end < 0 {
+		return 0, fmt.Errorf("unclosed expression %q", text)
+	}
+
+	return end, nil
+}
+
+// findClosing returns the index of the first closing character outside of
+// quoted strings, skipping the opening character at the start of the text.
+// It returns -1 if the text is not closed.
+func findClosing(text string, closing byte) int {
+	var quote byte
+
+	for i := 1; i < len(text); i++ {
+		c := text[i]
+
+		switch {
+		case quote != 0 && c == '\\':
+			i++
+		case quote != 0 && c == quote:
+			quote = 0
+		case quote != 0:
+		case c == '"' || c == '\'':
+			quote = c
+		case c == closing:
+			return i
+		}
+	}
+
+	return -1
+}
+
+func unquote(text string) (string, error) {
+	if strings.HasPrefix(text, "'") {
+		if len(text) < 2 || !strings.HasSuffix(text, "'") {
+			return "", fmt.Errorf("missing closing quote")
+		}
+
+		return text[1 : len(text)-1], nil
+	}
+
+	return strconv.Unquote(text)
+}
+
+type stepKind int
+
+const (
+	stepRoot stepKind = iota
+	stepField
+	stepWildcard
+	stepIndex
+	stepSlice
+)
+
+type step struct {
+	kind      stepKind
+	recursive bool
+
+	name       string
+	index      int
+	start, end *int
+}
+
+func parsePath(text string) (pathNode, error) {
+	original := text
+	path := pathNode{}
+
+	// paths are evaluated relative to the current element (@) unless they
+	// explicitly start at the root ($)
+	switch {
+	case strings.HasPrefix(text, "$"):
+		path = append(path, step{kind: stepRoot})
+		text = text[1:]
+	case strings.HasPrefix(text, "@"):
+		text = text[1:]
+	case strings.HasPrefix(text, ".") || strings.HasPrefix(text, "["):
+	default:
+		return nil, fmt.Errorf("invalid jsonpath expression %q: must start with \".\", \"[\", \"$\" or \"@\"", original)
+	}
+
+	for len(text) != 0 {
+		recursive := false
+
+		switch {
+		case strings.HasPrefix(text, ".."):
+			recursive = true
+			text = text[2:]
+		case strings.HasPrefix(text, "."):
+			text = text[1:]
+		}
+
+		if strings.HasPrefix(text, "[") {
+			end := findClosing(text, ']')
+			if end < 0 {
+				return nil, fmt.Errorf("invalid jsonpath expression %q: missing \"]\"", original)
+			}
+
+			s, err := parseBracket(text[1:end])
+			if err != nil {
+				return nil, fmt.Errorf("invalid jsonpath expression %q: %w", original, err)
+			}
+
+			s.recursive = recursive
+			path = append(path, s)
+			text = text[end+1:]
+			continue
+		}
+
+		end := strings.IndexAny(text, ".[")
+		if end < 0 {
+			end = len(text)
+		}
+
+		name := text[:end]
+		text = text[end:]
+
+		if len(name) == 0 {
+			if len(text) == 0 && !recursive {
+				break
+			}
+
+			return nil, fmt.Errorf("invalid jsonpath expression %q: empty field name", original)
+		}
+
+		if name == "*" {
+			path = append(path, step{kind: stepWildcard, recursive: recursive})
+		} else {
+			path = append(path, step{kind: stepField, name: name, recursive: recursive})
+		}
+	}
+
+	return path, nil
+}
+
+func parseBracket(text string) (step, error) {
+	text = strings.TrimSpace(text)
+
+	switch {
+	case text == "*":
+		return step{kind: stepWildcard}, nil
+
+	case strings.HasPrefix(text, "?"):
+		return step{}, fmt.Errorf("filter expressions are not supported")
+
+	case strings.HasPrefix(text, "'") || strings.HasPrefix(text, "\""):
+		name, err := unquote(text)
+		if err != nil {
+			return step{}, err
+		}
+
+		return step{kind: stepField, name: name}, nil
+
+	case strings.Contains(text, ":"):
+		parts := strings.SplitN(text, ":", 2)
+		s := step{kind: stepSlice}
+
+		for i, part := range parts {
+			part = strings.TrimSpace(part)
+			if len(part) == 0 {
+				continue
+			}
+
+			value, err := strconv.Atoi(part)
+			if err != nil {
+				return step{}, fmt.Errorf("invalid slice %q", text)
+			}
+
+			if i == 0 {
+				s.start = &value
+			} else {
+				s.end = &value
+			}
+		}
+
+		return s, nil
+	}
+
+	index, err := strconv.Atoi(text)
+	if err != nil {
+		return step{}, fmt.Errorf("invalid index %q", text)
+	}
+
+	return step{kind: stepIndex, index: index}, nil
+}
+
+func (p pathNode) evaluate(root, current interface{}, allowMissingKeys bool) ([]interface{}, error) {
+	values := []interface{}{current}
+
+	for _, s := range p {
+		if s.kind == stepRoot {
+			values = []interface{}{root}
+			continue
+		}
+
+		var next []interface{}
+		for _, value := range values {
+			candidates := []interface{}{value}
+			if s.recursive {
+				candidates = descendants(value)
+			}
+
+			for _, candidate := range candidates {
+				res, err := s.apply(candidate)
+				if err != nil {
+					return nil, err
+				}
+
+				if len(res) == 0 && s.kind == stepField && !s.recursive && !allowMissingKeys {
+					return nil, fmt.Errorf("field %q not found", s.name)
+				}
+
+				next = append(next, res...)
+			}
+		}
+
+		values = next
+	}
+
+	return values, nil
+}
+
+func (s step) apply(value interface{}) ([]interface{}, error) {
+	switch s.kind {
+	case stepField:
+		if object, ok := value.(map[string]interface{}); ok {
+			if child, ok := object[s.name]; ok {
+				return []interface{}{child}, nil
+			}
+		}
+
+		return nil, nil
+
+	case stepWildcard:
+		switch value := value.(type) {
+		case map[string]interface{}:
+			keys := make([]string, 0, len(value))
+			for key := range value {
+				keys = append(keys, key)
+			}
+
+			sort.Strings(keys)
+
+			res := make([]interface{}, len(keys))
+			for idx, key := range keys {
+				res[idx] = value[key]
+			}
+
+			return res, nil
+		case []interface{}:
+			return value, nil
+		}
+
+		return nil, nil
+
+	case stepIndex:
+		array, ok := value.([]interface{})
+		if !ok {
+			return nil, nil
+		}
+
+		index := s.index
+		if index < 0 {
+			index += len(array)
+		}
+
+		if index < 0 || index >= len(array) {
+			return nil, fmt.Errorf("index %d out of range for array of length %d", s.index, len(array))
+		}
+
+		return []interface{}{array[index]}, nil
+
+	case stepSlice:
+		array, ok := value.([]interface{})
+		if !ok {
+			return nil, nil
+		}
+
+		start, end := 0, len(array)
+		if s.start != nil {
+			start = clamp(*s.start, len(array))
+		}
+
+		if s.end != nil {
+			end = clamp(*s.end, len(array))
+		}
+
+		if start >= end {
+			return nil, nil
+		}
+
+		return array[start:end], nil
+	}
+
+	return nil, nil
+}
+
+func clamp(index int, length int) int {
+	if index < 0 {
+		index += length
+	}
+
+	if index < 0 {
+		return 0
+	}
+
+	if index > length {
+		return length
+	}
+
+	return index
+}
+
+func descendants(value interface{}) []interface{} {
+	res := []interface{}{value}
+
+	switch value := value.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(value))
+		for key := range value {
+			keys = append(keys, key)
+		}
+
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			res = append(res, descendants(value[key])...)
+		}
+	case []interface{}:
+		for _, child := range value {
+			res = append(res, descendants(child)...)
+		}
+	}
+
+	return res
+}
@@ -0,0 +1,191 @@
+package jsonpath
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+const testData = `{
+	"kind": "list",
+	"items": [
+		{"id": 1, "name": "web-1", "status": {"name": "running"}, "tags": ["a", "b"], "ip": "192.0.2.1"},
+		{"id": 2, "name": "web-2", "status": {"name": "stopped"}, "tags": []},
+		{"id": 3, "name": "db-1", "status": {"name": "running"}, "tags": ["c"]}
+	],
+	"meta": {"a]b": "bracket", "a.b": "dot", "total": 3}
+}`
+
+func TestExecute(t *testing.T) {
+	var data interface{}
+
+	decoder := json.NewDecoder(strings.NewReader(testData))
+	decoder.UseNumber()
+	if err := decoder.Decode(&data); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		template string
+		expected string
+	}{
+		{name: "field", template: "{.kind}", expected: "list"},
+		{name: "root", template: "{$.meta.total}", expected: "3"},
+		{name: "bracket field", template: "{['kind']}", expected: "list"},
+		{name: "bracket field with dot", template: "{.meta['a.b']}", expected: "dot"},
+		{name: "bracket field with closing bracket", template: "{.meta['a]b']}", expected: "bracket"},
+		{name: "double quoted bracket field", template: `{.meta["a]b"]}`, expected: "bracket"},
+		{name: "wildcard", template: "{.items[*].name}", expected: "web-1 web-2 db-1"},
+		{name: "object wildcard", template: "{.items[0].status.*}", expected: "running"},
+		{name: "index", template: "{.items[1].name}", expected: "web-2"},
+		{name: "negative index", template: "{.items[-1].name}", expected: "db-1"},
+		{name: "slice", template: "{.items[0:2].id}", expected: "1 2"},
+		{name: "open slice", template: "{.items[1:].id}", expected: "2 3"},
+		{name: "negative slice", template: "{.items[-2:].id}", expected: "2 3"},
+		{name: "empty slice", template: "{.items[2:1].id}", expected: ""},
+		{name: "recursive descent", template: "{..status.name}", expected: "running stopped running"},
+		{name: "recursive wildcard", template: "{.items[0].tags..*}", expected: "a b"},
+		{name: "object", template: "{.items[0].status}", expected: `{"name":"running"}`},
+		{name: "text and literals", template: `id: {.items[0].id}{"\t"}{'name'}`, expected: "id: 1\tname"},
+		{
+			name:     "range",
+			template: `{range .items[*]}{.id}{"\t"}{.name}{"\n"}{end}`,
+			expected: "1\tweb-1\n2\tweb-2\n3\tdb-1\n",
+		},
+		{
+			name:     "nested range",
+			template: `{range .items[*]}{.name}:{range .tags[*]} {@}{end}{"\n"}{end}`,
+			expected: "web-1: a b\nweb-2:\ndb-1: c\n",
+		},
+		{name: "root inside range", template: `{range .items[0:2]}{$.kind}{end}`, expected: "listlist"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			template, err := Parse(tt.template)
+			if err != nil {
+				t.Fatalf("unexpected parse error: %v", err)
+			}
+
+			var out bytes.Buffer
+			if err := template.Execute(&out, data); err != nil {
+				t.Fatalf("unexpected execute error: %v", err)
+			}
+
+			if out.String() != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, out.String())
+			}
+		})
+	}
+}
+
+func TestExecute_Errors(t *testing.T) {
+	var data interface{}
+	if err := json.Unmarshal([]byte(testData), &data); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		template string
+	}{
+		{name: "unknown field", template: "{.unknown}"},
+		{name: "index out of range", template: "{.items[3]}"},
+		{name: "negative index out of range", template: "{.items[-4]}"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			template, err := Parse(tt.template)
+			if err != nil {
+				t.Fatalf("unexpected parse error: %v", err)
+			}
+
+			var out bytes.Buffer
+			if err := template.Execute(&out, data); err == nil {
+				t.Errorf("expected an error, got %q", out.String())
+			}
+		})
+	}
+}
+
+func TestExecute_MissingKeys(t *testing.T) {
+	var data interface{}
+	if err := json.Unmarshal([]byte(testData), &data); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		template string
+		allow    bool
+		expected string
+		err      bool
+	}{
+		{name: "top level", template: "{.unknown}", err: true},
+		{name: "top level allowed", template: "{.unknown}", allow: true, expected: ""},
+		{name: "wildcard", template: "{.items[*].ip}", err: true},
+		{name: "wildcard allowed", template: "{.items[*].ip}", allow: true, expected: "192.0.2.1"},
+		{name: "slice", template: "{.items[1:].ip}", err: true},
+		{name: "range", template: `{range .items[*]}{.ip}{"\n"}{end}`, err: true},
+		{name: "range allowed", template: `{range .items[*]}{.ip}{"\n"}{end}`, allow: true, expected: "192.0.2.1\n\n\n"},
+		{name: "nested", template: "{.items[*].status.unknown}", err: true},
+		{name: "recursive descent", template: "{..ip}", expected: "192.0.2.1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			template, err := Parse(tt.template)
+			if err != nil {
+				t.Fatalf("unexpected parse error: %v", err)
+			}
+
+			var out bytes.Buffer
+			err = template.AllowMissingKeys(tt.allow).Execute(&out, data)
+			if tt.err {
+				if err == nil {
+					t.Errorf("expected an error, got %q", out.String())
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected execute error: %v", err)
+			}
+
+			if out.String() != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, out.String())
+			}
+		})
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+	}{
+		{name: "unclosed expression", template: "{.items"},
+		{name: "unclosed quote", template: `{"text}`},
+		{name: "missing end", template: "{range .items[*]}{.id}"},
+		{name: "end without range", template: "{.kind}{end}"},
+		{name: "invalid start", template: "{items}"},
+		{name: "missing bracket", template: "{.items[0}"},
+		{name: "quoted bracket without closing", template: "{.meta['a]b'}"},
+		{name: "invalid index", template: "{.items[x]}"},
+		{name: "invalid slice", template: "{.items[1:x]}"},
+		{name: "filter expression", template: "{.items[?(@.id==1)]}"},
+		{name: "empty field", template: "{.items..}"},
+		{name: "invalid literal", template: `{"\q"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Parse(tt.template); err == nil {
+				t.Errorf("expected an error for %q", tt.template)
+			}
+		})
+	}
+}
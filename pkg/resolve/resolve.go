@@ -0,0 +1,158 @@
+// Package resolve resolves user supplied terms, like the id or name of a
+// server, to the items they refer to.
+package resolve
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"sync"
+
+	"github.com/flowswiss/goclient"
+
+	"github.com/flowswiss/cli/v2/pkg/filter"
+)
+
+// ListFunc lists all items of a collection.
+type ListFunc[T any] func(ctx context.Context) ([]T, error)
+
+// GetFunc fetches a single item of a collection by its id.
+type GetFunc[T any] func(ctx context.Context, id int) (T, error)
+
+// Resolver resolves terms to the items of a collection. The list of items is
+// fetched at most once and shared between all lookups, so a resolver should
+// only live as long as the items are not expected to change, e.g. for the
+// duration of a single command. A Resolver is safe for concurrent use.
+type Resolver[T filter.Filterable] struct {
+	list ListFunc[T]
+	get  GetFunc[T]
+
+	mutex  sync.Mutex
+	items  []T
+	loaded bool
+}
+
+// New creates a resolver using list to fetch the items of the collection.
+// If get is not nil, numeric terms are fetched directly by their id instead
+// of listing the whole collection.
+func New[T filter.Filterable](list ListFunc[T], get GetFunc[T]) *Resolver[T] {
+	return &Resolver[T]{
+		list: list,
+		get:  get,
+	}
+}
+
+// List returns all items of the collection. The items are only fetched on the
+// first call, subsequent calls return the same items.
+func (r *Resolver[T]) List(ctx context.Context) ([]T, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if r.loaded {
+		return r.items, nil
+	}
+
+	items, err := r.list(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	r.items = items
+	r.loaded = true
+	return items, nil
+}
+
+// Find resolves the term to a single item using filter.FindOne. If the term
+// is a numeric id and the items have not been listed yet, the item is fetched
+// directly. If no item with this id exists, the term is looked up in the list
+// of all items instead, as it might be the name of another item.
+func (r *Resolver[T]) Find(ctx context.Context, term string) (T, error) {
+	if id, err := strconv.Atoi(term); err == nil && id > 0 && r.get != nil && !r.isLoaded() {
+		item, err := r.get(ctx, id)
+		if err == nil || !isNotFound(err) {
+			return item, err
+		}
+	}
+
+	items, err := r.List(ctx)
+	if err != nil {
+		var res T
+		return res, err
+	}
+
+	return filter.FindOne(items, term)
+}
+
+// Reset drops the memoized items, so that they are fetched again on the next
+// lookup.
+func (r *Resolver[T]) Reset() {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.items = nil
+	r.loaded = false
+}
+
+func (r *Resolver[T]) isLoaded() bool {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return r.loaded
+}
+
+func isNotFound(err error) bool {
+	var apiErr goclient.APIError
+	if !errors.As(err, &apiErr) || apiErr.Response() == nil {
+		return false
+	}
+
+	return apiErr.Response().StatusCode == http.StatusNotFound
+}
+
+// All runs the given functions concurrently and waits for all of them to
+// complete. The context passed to the functions is cancelled as soon as one
+// of them fails. The error of the first function in argument order which
+// failed is returned.
+func All(ctx context.Context, fns ...func(ctx context.Context) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	errs := make([]error, len(fns))
+
+	wg := sync.WaitGroup{}
+	wg.Add(len(fns))
+
+	for idx, fn := range fns {
+		go func(idx int, fn func(ctx context.Context) error) {
+			defer wg.Done()
+
+			if err := fn(ctx); err != nil {
+				errs[idx] = err
+				cancel()
+			}
+		}(idx, fn)
+	}
+
+	wg.Wait()
+
+	// prefer the original error over the cancellation errors caused by it
+	var cancelled error
+	for _, err := range errs {
+		if err == nil {
+			continue
+		}
+
+		if errors.Is(err, context.Canceled) && ctx.Err() != nil {
+			if cancelled == nil {
+				cancelled = err
+			}
+
+			continue
+		}
+
+		return err
+	}
+
+	return cancelled
+}